@@ -2,10 +2,9 @@ module github.com/inetmanageai/utils
 
 go 1.22.2
 
-require (
-	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0
-	golang.org/x/image v0.18.0
-)
+require golang.org/x/image v0.18.0
+
+require golang.org/x/text v0.16.0 // indirect
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -0,0 +1,94 @@
+// Package annotation สำหรับอ่านและเขียน annotation ในรูปแบบของ dataset มาตรฐาน (COCO, Pascal VOC, YOLO)
+// ให้อยู่ในรูป []mimage.PlotDataModel เพื่อใช้กับ mimage.PlotImage ได้โดยตรง
+package annotation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/inetmanageai/utils/mimage"
+)
+
+var (
+	ErrMissingSize  = errors.New("annotation: image size is required")
+	ErrUnknownClass = errors.New("annotation: unknown class")
+	// error เมื่อชื่อภาพใน Dataset ออกไปนอก directory เช่นมี ".." หรือเป็น absolute path
+	ErrInvalidName = errors.New("annotation: image name must be a relative path inside the directory")
+)
+
+// annotation ของทั้ง dataset โดย key เป็นชื่อไฟล์ภาพเทียบกับ directory ของภาพ
+// (อาจมี directory ย่อยได้ เช่น file_name ของ COCO แบบ "train2017/x.jpg")
+type Dataset map[string][]mimage.PlotDataModel
+
+var extensions = map[string]string{
+	"jpeg": ".jpg",
+	"png":  ".png",
+	"bmp":  ".bmp",
+	"tiff": ".tiff",
+}
+
+// สำหรับวาด annotation ของทุกภาพใน ds จาก imageDir แล้วบันทึกลง outDir
+// ไฟล์ผลลัพธ์ใช้ชื่อและ directory ย่อยเดิม แต่นามสกุลเปลี่ยนตาม format ที่ได้จาก mimage.PlotImage
+// ชื่อภาพที่ออกไปนอก directory (เช่น "../x.jpg") จะคืน ErrInvalidName
+func PlotDataset(ctx context.Context, ds Dataset, imageDir, outDir string, opts ...mimage.Option) error {
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return err
+	}
+
+	for name, plots := range ds {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("%w: %q", ErrInvalidName, name)
+		}
+
+		result, format, err := mimage.PlotImage(ctx, mimage.FileSource{Path: filepath.Join(imageDir, name)}, plots, opts...)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		out, err := outputPath(outDir, name, extensions[format])
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(out, result, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// path ของไฟล์ผลลัพธ์ของภาพ name ใน dir โดยเปลี่ยนนามสกุลเป็น ext และสร้าง directory ย่อยให้
+// name มาจากไฟล์ annotation จึงต้องไม่ออกไปนอก dir (เช่น "../x.jpg") ไม่เช่นนั้นจะคืน ErrInvalidName
+func outputPath(dir, name, ext string) (string, error) {
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	path := filepath.Join(dir, strings.TrimSuffix(name, filepath.Ext(name))+ext)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// คืนค่ากรอบของ p เป็น pixel xyxy บนภาพขนาด size โดยใช้ขอบเขตของ Shape หรือ Box ถ้ามี
+// Box ที่เป็นสัดส่วนต้องรู้ขนาดภาพ จึงคืน ErrMissingSize ถ้า size เป็นศูนย์
+func pixelBox(p mimage.PlotDataModel, size image.Point) (mimage.Box, error) {
+	if p.Shape != nil {
+		return mimage.BoxFromRect(p.Shape.Bounds()), nil
+	}
+	if p.Box == nil {
+		return mimage.BoxFromRect(p.Rect.Canon()), nil
+	}
+	if p.Box.Normalized && (size.X <= 0 || size.Y <= 0) {
+		return mimage.Box{}, ErrMissingSize
+	}
+	return p.Box.Denormalize(size).To(mimage.FormatXYXY), nil
+}
@@ -0,0 +1,169 @@
+package annotation
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/inetmanageai/utils/mimage"
+)
+
+type cocoImage struct {
+	ID       int    `json:"id"`
+	FileName string `json:"file_name"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+}
+
+type cocoAnnotation struct {
+	ID         int        `json:"id"`
+	ImageID    int        `json:"image_id"`
+	CategoryID int        `json:"category_id"`
+	BBox       [4]float64 `json:"bbox"`
+	Area       float64    `json:"area"`
+	IsCrowd    int        `json:"iscrowd"`
+	Score      *float64   `json:"score,omitempty"`
+}
+
+type cocoCategory struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type cocoFile struct {
+	Images      []cocoImage      `json:"images"`
+	Annotations []cocoAnnotation `json:"annotations"`
+	Categories  []cocoCategory   `json:"categories"`
+}
+
+// สำหรับอ่านไฟล์ COCO annotations JSON (images, annotations, categories)
+// bbox ของ COCO เป็น pixel xywh จะถูกเก็บเป็น Box แบบ FormatXYWH โดยไม่ปัดเศษ
+// และจะอ่าน score ด้วยถ้ามี (เช่นไฟล์ผลลัพธ์ของ model)
+func ReadCOCO(r io.Reader) (Dataset, error) {
+	var f cocoFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, err
+	}
+
+	names := make(map[int]string, len(f.Images))
+	ds := make(Dataset, len(f.Images))
+	for _, img := range f.Images {
+		names[img.ID] = img.FileName
+		ds[img.FileName] = []mimage.PlotDataModel{}
+	}
+	categories := make(map[int]string, len(f.Categories))
+	for _, c := range f.Categories {
+		categories[c.ID] = c.Name
+	}
+
+	for _, a := range f.Annotations {
+		name, ok := names[a.ImageID]
+		if !ok {
+			continue
+		}
+		box := mimage.NewBox(mimage.FormatXYWH, false, a.BBox[0], a.BBox[1], a.BBox[2], a.BBox[3])
+		p := mimage.PlotDataModel{Box: &box, Class: categories[a.CategoryID]}
+		if a.Score != nil {
+			p.Score = *a.Score
+		}
+		ds[name] = append(ds[name], p)
+	}
+
+	return ds, nil
+}
+
+// สำหรับอ่านไฟล์ COCO annotations JSON จาก path
+func ReadCOCOFile(path string) (Dataset, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadCOCO(f)
+}
+
+// สำหรับเขียน ds เป็น COCO annotations JSON
+// sizes คือขนาดของแต่ละภาพ (ต้องมีถ้ากรอบเป็น Box แบบสัดส่วน) และ classes กำหนดลำดับ category id เริ่มที่ 1
+// class ที่ไม่อยู่ใน classes จะถูกเพิ่มต่อท้ายเรียงตามชื่อ ส่วนกรอบที่ไม่มี Class จะคืน ErrUnknownClass
+func WriteCOCO(w io.Writer, ds Dataset, sizes map[string]image.Point, classes []string) error {
+	names := make([]string, 0, len(ds))
+	for name := range ds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	f := cocoFile{
+		Images:      make([]cocoImage, 0, len(names)),
+		Annotations: make([]cocoAnnotation, 0),
+		Categories:  make([]cocoCategory, 0, len(classes)),
+	}
+	categories := make(map[string]int, len(classes))
+	for _, c := range classes {
+		if _, ok := categories[c]; !ok {
+			categories[c] = len(categories) + 1
+			f.Categories = append(f.Categories, cocoCategory{ID: categories[c], Name: c})
+		}
+	}
+	var extra []string
+	for _, name := range names {
+		for _, p := range ds[name] {
+			if p.Class == "" {
+				return fmt.Errorf("%s: %w: %q", name, ErrUnknownClass, p.Class)
+			}
+			if _, ok := categories[p.Class]; !ok {
+				categories[p.Class] = 0
+				extra = append(extra, p.Class)
+			}
+		}
+	}
+	sort.Strings(extra)
+	for _, c := range extra {
+		categories[c] = len(f.Categories) + 1
+		f.Categories = append(f.Categories, cocoCategory{ID: categories[c], Name: c})
+	}
+
+	for i, name := range names {
+		size := sizes[name]
+		f.Images = append(f.Images, cocoImage{ID: i + 1, FileName: name, Width: size.X, Height: size.Y})
+		for _, p := range ds[name] {
+			box, err := pixelBox(p, size)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			v := box.To(mimage.FormatXYWH).Values
+			a := cocoAnnotation{
+				ID:         len(f.Annotations) + 1,
+				ImageID:    i + 1,
+				CategoryID: categories[p.Class],
+				BBox:       v,
+				Area:       v[2] * v[3],
+			}
+			if p.Score > 0 {
+				score := p.Score
+				a.Score = &score
+			}
+			f.Annotations = append(f.Annotations, a)
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(f)
+}
+
+// สำหรับเขียน ds เป็นไฟล์ COCO annotations JSON ที่ path
+func WriteCOCOFile(path string, ds Dataset, sizes map[string]image.Point, classes []string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteCOCO(f, ds, sizes, classes); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
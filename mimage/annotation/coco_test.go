@@ -0,0 +1,120 @@
+package annotation_test
+
+import (
+	"bytes"
+	"image"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/inetmanageai/utils/mimage/annotation"
+	"github.com/stretchr/testify/assert"
+)
+
+func xywh(x, y, w, h float64) *mimage.Box {
+	box := mimage.NewBox(mimage.FormatXYWH, false, x, y, w, h)
+	return &box
+}
+
+func TestReadCOCOFile(t *testing.T) {
+	// --------------- Act ---------------
+	ds, err := annotation.ReadCOCOFile("../../testdata/annotation/coco.json")
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.Equal(t, annotation.Dataset{
+		"image_test.jpg": {
+			{Box: xywh(10, 20, 50, 60), Class: "face"},
+			{Box: xywh(80, 100, 100, 150), Class: "person", Score: 0.87},
+		},
+		"image_test.png": {
+			{Box: xywh(30.4, 30.6, 40, 40), Class: "face"},
+		},
+	}, ds)
+	assert.Equal(t, image.Rect(30, 31, 69, 70), ds["image_test.png"][0].Box.Rect(image.Rectangle{}))
+}
+
+func TestReadCOCO(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Input         string
+		Expected      annotation.Dataset
+		ExpectedError bool
+	}{
+		{
+			Name:     "Image without annotations",
+			Input:    `{"images": [{"id": 1, "file_name": "a.jpg"}], "annotations": [], "categories": []}`,
+			Expected: annotation.Dataset{"a.jpg": {}},
+		},
+		{
+			Name:     "Annotation for unknown image is skipped",
+			Input:    `{"images": [], "annotations": [{"image_id": 9, "category_id": 1, "bbox": [0, 0, 1, 1]}]}`,
+			Expected: annotation.Dataset{},
+		},
+		{
+			Name:          "Invalid JSON",
+			Input:         `{"images": [`,
+			ExpectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result, err := annotation.ReadCOCO(strings.NewReader(tt.Input))
+
+			// --------------- Assert ---------------
+			if tt.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.Expected, result)
+		})
+	}
+}
+
+func TestWriteCOCO(t *testing.T) {
+	// --------------- Arrange ---------------
+	box := mimage.NewBox(mimage.FormatCXCYWH, true, 0.5, 0.5, 0.5, 0.5)
+	ds := annotation.Dataset{
+		"b.jpg": {{Rect: image.Rect(10, 20, 60, 80), Class: "face", Score: 0.9}},
+		"a.jpg": {{Box: &box, Class: "car"}, {Rect: image.Rect(0, 0, 10, 10), Class: "person"}},
+	}
+	sizes := map[string]image.Point{"a.jpg": image.Pt(200, 100), "b.jpg": image.Pt(100, 100)}
+
+	// --------------- Act ---------------
+	var buf bytes.Buffer
+	err := annotation.WriteCOCO(&buf, ds, sizes, []string{"person", "face"})
+	result, readErr := annotation.ReadCOCO(&buf)
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.NoError(t, readErr)
+	assert.Equal(t, annotation.Dataset{
+		"a.jpg": {
+			{Box: xywh(50, 25, 100, 50), Class: "car"},
+			{Box: xywh(0, 0, 11, 11), Class: "person"},
+		},
+		"b.jpg": {{Box: xywh(10, 20, 51, 61), Class: "face", Score: 0.9}},
+	}, result)
+}
+
+func TestWriteCOCOMissingSize(t *testing.T) {
+	// --------------- Arrange ---------------
+	box := mimage.NewBox(mimage.FormatCXCYWH, true, 0.5, 0.5, 0.5, 0.5)
+
+	// --------------- Act ---------------
+	err := annotation.WriteCOCO(io.Discard, annotation.Dataset{"a.jpg": {{Box: &box, Class: "car"}}}, nil, nil)
+
+	// --------------- Assert ---------------
+	assert.ErrorIs(t, err, annotation.ErrMissingSize)
+}
+
+func TestWriteCOCOEmptyClass(t *testing.T) {
+	// --------------- Act ---------------
+	err := annotation.WriteCOCO(io.Discard, annotation.Dataset{"a.jpg": {{Rect: image.Rect(0, 0, 10, 10)}}}, nil, []string{"face"})
+
+	// --------------- Assert ---------------
+	assert.ErrorIs(t, err, annotation.ErrUnknownClass)
+}
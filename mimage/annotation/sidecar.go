@@ -0,0 +1,189 @@
+package annotation
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"os"
+
+	"github.com/inetmanageai/utils/mimage"
+)
+
+// นามสกุลที่ต่อท้ายชื่อไฟล์ภาพเพื่อเป็นไฟล์ sidecar เช่น photo.jpg -> photo.jpg.json
+const SidecarExt = ".json"
+
+var boxFormats = map[mimage.BoxFormat]string{
+	mimage.FormatXYXY:   "xyxy",
+	mimage.FormatXYWH:   "xywh",
+	mimage.FormatCXCYWH: "cxcywh",
+}
+
+type sidecarFile struct {
+	Version int           `json:"version"`
+	Width   int           `json:"width,omitempty"`
+	Height  int           `json:"height,omitempty"`
+	Plots   []sidecarPlot `json:"plots"`
+}
+
+type sidecarPlot struct {
+	Rect  [4]int        `json:"rect"` // x1, y1, x2, y2 เป็น pixel
+	Box   *sidecarBox   `json:"box,omitempty"`
+	Label string        `json:"label,omitempty"`
+	Class string        `json:"class,omitempty"`
+	Score float64       `json:"score,omitempty"`
+	Style *sidecarStyle `json:"style,omitempty"`
+}
+
+type sidecarBox struct {
+	Format     string     `json:"format"`
+	Normalized bool       `json:"normalized,omitempty"`
+	Values     [4]float64 `json:"values"`
+}
+
+// สีเก็บเป็น "#rrggbbaa"
+type sidecarStyle struct {
+	Color           string  `json:"color,omitempty"`
+	Thickness       int     `json:"thickness,omitempty"`
+	FontSize        float64 `json:"font_size,omitempty"`
+	LabelColor      string  `json:"label_color,omitempty"`
+	FillColor       string  `json:"fill_color,omitempty"`
+	LabelBackground string  `json:"label_background,omitempty"`
+}
+
+// สำหรับเขียน plots เป็น JSON ของ mimage เอง ซึ่งเก็บ Box และ Style ไว้ครบ
+// ถ้ากำหนด size กรอบที่เป็น Box จะมี rect เป็น pixel ตามขนาดภาพด้วย ส่วน Shape จะเก็บเฉพาะขอบเขตเป็น rect
+func WriteSidecar(w io.Writer, plots []mimage.PlotDataModel, size image.Point) error {
+	f := sidecarFile{Version: 1, Width: size.X, Height: size.Y, Plots: make([]sidecarPlot, 0, len(plots))}
+	for _, p := range plots {
+		rect := p.Rect
+		if p.Shape != nil {
+			rect = p.Shape.Bounds()
+		} else if p.Box != nil && (!p.Box.Normalized || size.X > 0 && size.Y > 0) {
+			rect = p.Box.Rect(image.Rectangle{Max: size})
+		}
+		sp := sidecarPlot{
+			Rect:  [4]int{rect.Min.X, rect.Min.Y, rect.Max.X, rect.Max.Y},
+			Label: p.Label,
+			Class: p.Class,
+			Score: p.Score,
+		}
+		if p.Box != nil {
+			sp.Box = &sidecarBox{Format: boxFormats[p.Box.Format], Normalized: p.Box.Normalized, Values: p.Box.Values}
+		}
+		if p.Style != (mimage.PlotStyle{}) {
+			sp.Style = &sidecarStyle{
+				Color:           formatColor(p.Style.Color),
+				Thickness:       p.Style.Thickness,
+				FontSize:        p.Style.FontSize,
+				LabelColor:      formatColor(p.Style.LabelColor),
+				FillColor:       formatColor(p.Style.FillColor),
+				LabelBackground: formatColor(p.Style.LabelBackground),
+			}
+		}
+		f.Plots = append(f.Plots, sp)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(f)
+}
+
+// สำหรับอ่าน JSON ที่เขียนด้วย WriteSidecar กลับเป็น plots พร้อมขนาดภาพ (ถ้ามี)
+func ReadSidecar(r io.Reader) (plots []mimage.PlotDataModel, size image.Point, err error) {
+	var f sidecarFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, image.Point{}, err
+	}
+
+	plots = make([]mimage.PlotDataModel, 0, len(f.Plots))
+	for i, sp := range f.Plots {
+		p := mimage.PlotDataModel{
+			Rect:  image.Rect(sp.Rect[0], sp.Rect[1], sp.Rect[2], sp.Rect[3]),
+			Label: sp.Label,
+			Class: sp.Class,
+			Score: sp.Score,
+		}
+		if sp.Box != nil {
+			format, ok := parseBoxFormat(sp.Box.Format)
+			if !ok {
+				return nil, image.Point{}, fmt.Errorf("plot %d: unknown box format %q", i, sp.Box.Format)
+			}
+			box := mimage.Box{Format: format, Normalized: sp.Box.Normalized, Values: sp.Box.Values}
+			p.Box = &box
+		}
+		if sp.Style != nil {
+			p.Style.Thickness = sp.Style.Thickness
+			p.Style.FontSize = sp.Style.FontSize
+			for _, c := range []struct {
+				dst *color.Color
+				src string
+			}{
+				{&p.Style.Color, sp.Style.Color},
+				{&p.Style.LabelColor, sp.Style.LabelColor},
+				{&p.Style.FillColor, sp.Style.FillColor},
+				{&p.Style.LabelBackground, sp.Style.LabelBackground},
+			} {
+				if *c.dst, err = parseColor(c.src); err != nil {
+					return nil, image.Point{}, fmt.Errorf("plot %d: %w", i, err)
+				}
+			}
+		}
+		plots = append(plots, p)
+	}
+
+	return plots, image.Pt(f.Width, f.Height), nil
+}
+
+// สำหรับเขียน sidecar ไว้ข้างไฟล์ภาพ imagePath (imagePath + SidecarExt)
+func WriteSidecarFile(imagePath string, plots []mimage.PlotDataModel, size image.Point) error {
+	f, err := os.Create(imagePath + SidecarExt)
+	if err != nil {
+		return err
+	}
+	if err := WriteSidecar(f, plots, size); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// สำหรับอ่าน sidecar ที่อยู่ข้างไฟล์ภาพ imagePath
+func ReadSidecarFile(imagePath string) ([]mimage.PlotDataModel, image.Point, error) {
+	f, err := os.Open(imagePath + SidecarExt)
+	if err != nil {
+		return nil, image.Point{}, err
+	}
+	defer f.Close()
+
+	return ReadSidecar(f)
+}
+
+func parseBoxFormat(s string) (mimage.BoxFormat, bool) {
+	for format, name := range boxFormats {
+		if name == s {
+			return format, true
+		}
+	}
+	return 0, false
+}
+
+func formatColor(c color.Color) string {
+	if c == nil {
+		return ""
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("#%02x%02x%02x%02x", n.R, n.G, n.B, n.A)
+}
+
+func parseColor(s string) (color.Color, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var n color.NRGBA
+	if _, err := fmt.Sscanf(s, "#%02x%02x%02x%02x", &n.R, &n.G, &n.B, &n.A); err != nil {
+		return nil, fmt.Errorf("invalid color %q", s)
+	}
+	return n, nil
+}
@@ -0,0 +1,65 @@
+package annotation_test
+
+import (
+	"image"
+	"image/color"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/inetmanageai/utils/mimage/annotation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSidecarFileRoundTrip(t *testing.T) {
+	// --------------- Arrange ---------------
+	path := filepath.Join(t.TempDir(), "a.jpg")
+	box := mimage.NewBox(mimage.FormatCXCYWH, true, 0.5, 0.5, 0.5, 0.5)
+	plots := []mimage.PlotDataModel{
+		{
+			Rect:  image.Rect(10, 20, 60, 80),
+			Label: "สมชาย",
+			Class: "face",
+			Score: 0.97,
+			Style: mimage.PlotStyle{
+				Color:     color.NRGBA{0, 255, 0, 255},
+				Thickness: 3,
+				FillColor: color.NRGBA{0, 255, 0, 64},
+			},
+		},
+		{Box: &box, Class: "person"},
+	}
+
+	// --------------- Act ---------------
+	err := annotation.WriteSidecarFile(path, plots, image.Pt(200, 100))
+	result, size, readErr := annotation.ReadSidecarFile(path)
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.NoError(t, readErr)
+	assert.Equal(t, image.Pt(200, 100), size)
+	assert.Equal(t, plots[0], result[0])
+	assert.Equal(t, &box, result[1].Box)
+	assert.Equal(t, image.Rect(50, 25, 149, 74), result[1].Rect)
+}
+
+func TestReadSidecarInvalid(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Input string
+	}{
+		{Name: "Invalid JSON", Input: `{"plots": [`},
+		{Name: "Unknown box format", Input: `{"plots": [{"rect": [0, 0, 1, 1], "box": {"format": "polar", "values": [0, 0, 0, 0]}}]}`},
+		{Name: "Invalid color", Input: `{"plots": [{"rect": [0, 0, 1, 1], "style": {"color": "red"}}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			_, _, err := annotation.ReadSidecar(strings.NewReader(tt.Input))
+
+			// --------------- Assert ---------------
+			assert.Error(t, err)
+		})
+	}
+}
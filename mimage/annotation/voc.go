@@ -0,0 +1,165 @@
+package annotation
+
+import (
+	"encoding/xml"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/inetmanageai/utils/mimage"
+)
+
+type vocAnnotation struct {
+	XMLName  xml.Name    `xml:"annotation"`
+	Folder   string      `xml:"folder,omitempty"`
+	Filename string      `xml:"filename"`
+	Size     vocSize     `xml:"size"`
+	Objects  []vocObject `xml:"object"`
+}
+
+type vocSize struct {
+	Width  int `xml:"width"`
+	Height int `xml:"height"`
+	Depth  int `xml:"depth"`
+}
+
+type vocObject struct {
+	Name      string    `xml:"name"`
+	Pose      string    `xml:"pose,omitempty"`
+	Truncated int       `xml:"truncated"`
+	Difficult int       `xml:"difficult"`
+	Score     *float64  `xml:"score,omitempty"`
+	BndBox    vocBndBox `xml:"bndbox"`
+}
+
+type vocBndBox struct {
+	XMin float64 `xml:"xmin"`
+	YMin float64 `xml:"ymin"`
+	XMax float64 `xml:"xmax"`
+	YMax float64 `xml:"ymax"`
+}
+
+// สำหรับอ่านไฟล์ Pascal VOC XML 1 ภาพ คืนค่าชื่อไฟล์ภาพจาก <filename> พร้อมกรอบทั้งหมด
+// ถ้ามี <score> ใน object จะอ่านเป็น Score ด้วย
+func ReadVOC(r io.Reader) (filename string, plots []mimage.PlotDataModel, err error) {
+	var a vocAnnotation
+	if err := xml.NewDecoder(r).Decode(&a); err != nil {
+		return "", nil, err
+	}
+
+	plots = make([]mimage.PlotDataModel, 0, len(a.Objects))
+	for _, o := range a.Objects {
+		p := mimage.PlotDataModel{
+			Rect: image.Rect(
+				int(math.Round(o.BndBox.XMin)), int(math.Round(o.BndBox.YMin)),
+				int(math.Round(o.BndBox.XMax)), int(math.Round(o.BndBox.YMax)),
+			),
+			Class: o.Name,
+		}
+		if o.Score != nil {
+			p.Score = *o.Score
+		}
+		plots = append(plots, p)
+	}
+
+	return a.Filename, plots, nil
+}
+
+// สำหรับอ่านไฟล์ .xml ทุกไฟล์ใน dir เป็น Dataset
+func ReadVOCDir(dir string) (Dataset, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.xml"))
+	if err != nil {
+		return nil, err
+	}
+
+	ds := make(Dataset, len(paths))
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		name, plots, err := ReadVOC(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		ds[name] = plots
+	}
+
+	return ds, nil
+}
+
+// สำหรับเขียนกรอบของภาพ filename ขนาด size เป็น Pascal VOC XML
+// พิกัดของ VOC เป็น pixel จึงปัดเศษเป็นจำนวนเต็ม และเขียน <score> เฉพาะกรอบที่มี Score
+// กรอบที่ไม่มี Class จะคืน ErrUnknownClass
+func WriteVOC(w io.Writer, filename string, size image.Point, plots []mimage.PlotDataModel) error {
+	a := vocAnnotation{
+		Filename: filename,
+		Size:     vocSize{Width: size.X, Height: size.Y, Depth: 3},
+		Objects:  make([]vocObject, 0, len(plots)),
+	}
+	for _, p := range plots {
+		if p.Class == "" {
+			return fmt.Errorf("%w: %q", ErrUnknownClass, p.Class)
+		}
+		box, err := pixelBox(p, size)
+		if err != nil {
+			return err
+		}
+		// xmax, ymax ของ VOC เป็น pixel สุดท้ายเหมือน PlotDataModel.Rect
+		r := box.Rect(image.Rectangle{})
+		o := vocObject{
+			Name: p.Class,
+			BndBox: vocBndBox{
+				XMin: float64(r.Min.X), YMin: float64(r.Min.Y),
+				XMax: float64(r.Max.X), YMax: float64(r.Max.Y),
+			},
+		}
+		if p.Score > 0 {
+			score := p.Score
+			o.Score = &score
+		}
+		a.Objects = append(a.Objects, o)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+	if err := enc.Encode(a); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// สำหรับเขียน ds เป็นไฟล์ .xml ต่อภาพลงใน dir โดยใช้ชื่อและ directory ย่อยเดียวกับภาพ
+func WriteVOCDir(dir string, ds Dataset, sizes map[string]image.Point) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	for name, plots := range ds {
+		path, err := outputPath(dir, name, ".xml")
+		if err != nil {
+			return err
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		err = WriteVOC(f, name, sizes[name], plots)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
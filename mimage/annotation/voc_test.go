@@ -0,0 +1,114 @@
+package annotation_test
+
+import (
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/inetmanageai/utils/mimage/annotation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadVOC(t *testing.T) {
+	// --------------- Arrange ---------------
+	f, err := os.Open("../../testdata/annotation/voc/image_test.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// --------------- Act ---------------
+	name, plots, err := annotation.ReadVOC(f)
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.Equal(t, "image_test.jpg", name)
+	assert.Equal(t, []mimage.PlotDataModel{
+		{Rect: image.Rect(10, 20, 60, 80), Class: "face"},
+		{Rect: image.Rect(80, 100, 180, 250), Class: "person", Score: 0.87},
+	}, plots)
+}
+
+func TestReadVOCInvalid(t *testing.T) {
+	// --------------- Act ---------------
+	_, _, err := annotation.ReadVOC(strings.NewReader("<annotation><object>"))
+
+	// --------------- Assert ---------------
+	assert.Error(t, err)
+}
+
+func TestReadVOCDir(t *testing.T) {
+	// --------------- Act ---------------
+	ds, err := annotation.ReadVOCDir("../../testdata/annotation/voc")
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.Len(t, ds, 1)
+	assert.Len(t, ds["image_test.jpg"], 2)
+}
+
+func TestWriteVOCDir(t *testing.T) {
+	// --------------- Arrange ---------------
+	dir := t.TempDir()
+	box := mimage.NewBox(mimage.FormatXYWH, false, 10.4, 20, 50, 60)
+	ds := annotation.Dataset{
+		"image_test.jpg": {
+			{Box: &box, Class: "face"},
+			{Rect: image.Rect(180, 250, 80, 100), Class: "person", Score: 0.87},
+		},
+	}
+
+	// --------------- Act ---------------
+	err := annotation.WriteVOCDir(dir, ds, map[string]image.Point{"image_test.jpg": image.Pt(200, 300)})
+	result, readErr := annotation.ReadVOCDir(dir)
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.NoError(t, readErr)
+	assert.Equal(t, annotation.Dataset{
+		"image_test.jpg": {
+			{Rect: image.Rect(10, 20, 59, 79), Class: "face"},
+			{Rect: image.Rect(80, 100, 180, 250), Class: "person", Score: 0.87},
+		},
+	}, result)
+}
+
+func TestWriteVOCDirSubdirectory(t *testing.T) {
+	// --------------- Arrange ---------------
+	dir := t.TempDir()
+	ds := annotation.Dataset{"train/x.jpg": {{Rect: image.Rect(10, 20, 59, 79), Class: "face"}}}
+
+	// --------------- Act ---------------
+	err := annotation.WriteVOCDir(dir, ds, map[string]image.Point{"train/x.jpg": image.Pt(200, 300)})
+	_, statErr := os.Stat(filepath.Join(dir, "train", "x.xml"))
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.NoError(t, statErr)
+}
+
+func TestWriteVOCEmptyClass(t *testing.T) {
+	// --------------- Act ---------------
+	err := annotation.WriteVOC(io.Discard, "a.jpg", image.Pt(10, 10), []mimage.PlotDataModel{{Rect: image.Rect(0, 0, 5, 5)}})
+
+	// --------------- Assert ---------------
+	assert.ErrorIs(t, err, annotation.ErrUnknownClass)
+}
+
+func TestWriteVOCDirInvalidName(t *testing.T) {
+	// --------------- Arrange ---------------
+	root := t.TempDir()
+	ds := annotation.Dataset{"../x.jpg": {{Rect: image.Rect(10, 20, 59, 79), Class: "face"}}}
+
+	// --------------- Act ---------------
+	err := annotation.WriteVOCDir(filepath.Join(root, "labels"), ds, nil)
+
+	// --------------- Assert ---------------
+	assert.ErrorIs(t, err, annotation.ErrInvalidName)
+	_, statErr := os.Stat(filepath.Join(root, "x.xml"))
+	assert.True(t, os.IsNotExist(statErr))
+}
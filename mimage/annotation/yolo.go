@@ -0,0 +1,160 @@
+package annotation
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/inetmanageai/utils/mslices"
+)
+
+// นามสกุลของไฟล์ภาพที่ ReadYOLODir จะจับคู่กับไฟล์ label
+var imageExtensions = []string{".jpg", ".jpeg", ".png", ".bmp", ".tif", ".tiff", ".webp"}
+
+// สำหรับอ่านไฟล์ label ของ YOLO (1 บรรทัดต่อ 1 กรอบ: "class cx cy w h [score]" เป็นค่าสัดส่วน 0-1)
+// classes ใช้แปลง class id เป็นชื่อ ถ้า id เกินจำนวนจะใช้ตัวเลขเป็นชื่อแทน
+// กรอบที่ได้กำหนด Box ไว้ จึงถูกแปลงเป็น pixel ตามขนาดภาพจริงตอนวาด
+func ReadYOLO(r io.Reader, classes []string) ([]mimage.PlotDataModel, error) {
+	plots := make([]mimage.PlotDataModel, 0)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 5 && len(fields) != 6 {
+			return nil, fmt.Errorf("line %d: expected 5 or 6 fields, got %d", line, len(fields))
+		}
+
+		id, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		values := make([]float64, len(fields)-1)
+		for i, f := range fields[1:] {
+			if values[i], err = strconv.ParseFloat(f, 64); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+
+		box := mimage.NewBox(mimage.FormatCXCYWH, true, values[0], values[1], values[2], values[3])
+		p := mimage.PlotDataModel{Box: &box, Class: strconv.Itoa(id)}
+		if id >= 0 && id < len(classes) {
+			p.Class = classes[id]
+		}
+		if len(values) == 5 {
+			p.Score = values[4]
+		}
+		plots = append(plots, p)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return plots, nil
+}
+
+// สำหรับอ่าน label ของทุกภาพใน imageDir จากไฟล์ .txt ชื่อเดียวกันใน labelDir
+// ภาพที่ไม่มีไฟล์ label จะไม่ถูกรวมใน Dataset
+func ReadYOLODir(labelDir, imageDir string, classes []string) (Dataset, error) {
+	entries, err := os.ReadDir(imageDir)
+	if err != nil {
+		return nil, err
+	}
+
+	ds := make(Dataset)
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || !mslices.Contains(imageExtensions, strings.ToLower(ext)) {
+			continue
+		}
+
+		f, err := os.Open(filepath.Join(labelDir, strings.TrimSuffix(e.Name(), ext)+".txt"))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		plots, err := ReadYOLO(f, classes)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		ds[e.Name()] = plots
+	}
+
+	return ds, nil
+}
+
+// สำหรับเขียนกรอบของภาพขนาด size เป็นไฟล์ label ของ YOLO
+// class ต้องอยู่ใน classes หรือเป็นตัวเลข class id ไม่เช่นนั้นจะคืน ErrUnknownClass
+// ต้องกำหนด size เสมอเพราะ YOLO เก็บค่าเป็นสัดส่วน ยกเว้นทุกกรอบเป็น Box แบบสัดส่วนอยู่แล้ว
+func WriteYOLO(w io.Writer, plots []mimage.PlotDataModel, size image.Point, classes []string) error {
+	bw := bufio.NewWriter(w)
+	for _, p := range plots {
+		_, id, err := mslices.Find(classes, func(c string) bool { return c == p.Class })
+		if err != nil {
+			n, err := strconv.Atoi(p.Class)
+			if err != nil || n < 0 {
+				return fmt.Errorf("%w: %q", ErrUnknownClass, p.Class)
+			}
+			id = n
+		}
+
+		var box mimage.Box
+		switch {
+		case p.Box != nil && p.Box.Normalized:
+			box = *p.Box
+		case size.X <= 0 || size.Y <= 0:
+			return ErrMissingSize
+		default:
+			b, err := pixelBox(p, size)
+			if err != nil {
+				return err
+			}
+			box = b.Normalize(size)
+		}
+		v := box.To(mimage.FormatCXCYWH).Values
+
+		fmt.Fprintf(bw, "%d %.6f %.6f %.6f %.6f", id, v[0], v[1], v[2], v[3])
+		if p.Score > 0 {
+			fmt.Fprintf(bw, " %.6f", p.Score)
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// สำหรับเขียน ds เป็นไฟล์ .txt ต่อภาพลงใน dir โดยใช้ชื่อและ directory ย่อยเดียวกับภาพ
+func WriteYOLODir(dir string, ds Dataset, sizes map[string]image.Point, classes []string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	for name, plots := range ds {
+		path, err := outputPath(dir, name, ".txt")
+		if err != nil {
+			return err
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		err = WriteYOLO(f, plots, sizes[name], classes)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package annotation_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/inetmanageai/utils/mimage/annotation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadYOLO(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Input         string
+		Classes       []string
+		Expected      []image.Rectangle
+		ExpectedClass []string
+		ExpectedScore []float64
+		ExpectedError bool
+	}{
+		{
+			Name:          "Labels with class names",
+			Input:         "0 0.5 0.5 0.5 0.5\n\n1 0.25 0.25 0.5 0.5 0.9\n",
+			Classes:       []string{"face", "person"},
+			Expected:      []image.Rectangle{image.Rect(50, 50, 149, 149), image.Rect(0, 0, 99, 99)},
+			ExpectedClass: []string{"face", "person"},
+			ExpectedScore: []float64{0, 0.9},
+		},
+		{
+			Name:          "Unknown class id",
+			Input:         "7 0.5 0.5 1 1",
+			Classes:       []string{"face"},
+			Expected:      []image.Rectangle{image.Rect(0, 0, 199, 199)},
+			ExpectedClass: []string{"7"},
+			ExpectedScore: []float64{0},
+		},
+		{
+			Name:          "Wrong number of fields",
+			Input:         "0 0.5 0.5",
+			ExpectedError: true,
+		},
+		{
+			Name:          "Invalid number",
+			Input:         "0 0.5 x 0.5 0.5",
+			ExpectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result, err := annotation.ReadYOLO(strings.NewReader(tt.Input), tt.Classes)
+
+			// --------------- Assert ---------------
+			if tt.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, result, len(tt.Expected))
+			for i, p := range result {
+				assert.Equal(t, tt.Expected[i], p.Box.Rect(image.Rect(0, 0, 200, 200)))
+				assert.Equal(t, tt.ExpectedClass[i], p.Class)
+				assert.Equal(t, tt.ExpectedScore[i], p.Score)
+			}
+		})
+	}
+}
+
+func TestReadYOLODirAndPlotDataset(t *testing.T) {
+	// --------------- Arrange ---------------
+	imageDir := t.TempDir()
+	outDir := filepath.Join(t.TempDir(), "out")
+	for _, name := range []string{"image_test.jpg", "image_test.webp"} {
+		data, err := os.ReadFile(filepath.Join("../../testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		os.WriteFile(filepath.Join(imageDir, strings.Replace(name, "image_test", "a", 1)), data, 0o644)
+	}
+	labelDir := t.TempDir()
+	label, _ := os.ReadFile("../../testdata/annotation/yolo/image_test.txt")
+	os.WriteFile(filepath.Join(labelDir, "a.txt"), label, 0o644)
+
+	// --------------- Act ---------------
+	ds, err := annotation.ReadYOLODir(labelDir, imageDir, []string{"face", "person"})
+	assert.NoError(t, err)
+	err = annotation.PlotDataset(context.Background(), ds, imageDir, outDir, mimage.WithLegend(mimage.LegendTopRight))
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.Len(t, ds, 2)
+	assert.Len(t, ds["a.jpg"], 2)
+	assert.Equal(t, image.Rect(10, 20, 59, 79), ds["a.jpg"][0].Box.Rect(image.Rect(0, 0, 200, 300)))
+	_, errJPEG := os.Stat(filepath.Join(outDir, "a.jpg"))
+	_, errPNG := os.Stat(filepath.Join(outDir, "a.png"))
+	assert.NoError(t, errJPEG)
+	assert.NoError(t, errPNG)
+}
+
+func TestPlotDatasetSubdirectory(t *testing.T) {
+	// --------------- Arrange ---------------
+	imageDir := t.TempDir()
+	outDir := t.TempDir()
+	data, err := os.ReadFile("../../testdata/image_test.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(imageDir, "train2017"), 0o755)
+	os.WriteFile(filepath.Join(imageDir, "train2017", "x.jpg"), data, 0o644)
+	ds := annotation.Dataset{"train2017/x.jpg": {{Rect: image.Rect(10, 20, 60, 80), Class: "face"}}}
+
+	// --------------- Act ---------------
+	err = annotation.PlotDataset(context.Background(), ds, imageDir, outDir)
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	_, statErr := os.Stat(filepath.Join(outDir, "train2017", "x.jpg"))
+	assert.NoError(t, statErr)
+}
+
+func TestPlotDatasetInvalidName(t *testing.T) {
+	// --------------- Arrange ---------------
+	root := t.TempDir()
+	outDir := filepath.Join(root, "a", "b")
+
+	// --------------- Act ---------------
+	err := annotation.PlotDataset(context.Background(), annotation.Dataset{"../../x.jpg": nil}, t.TempDir(), outDir)
+
+	// --------------- Assert ---------------
+	assert.ErrorIs(t, err, annotation.ErrInvalidName)
+	_, statErr := os.Stat(filepath.Join(root, "x.jpg"))
+	assert.True(t, os.IsNotExist(statErr))
+}
+
+func TestPlotDatasetMissingImage(t *testing.T) {
+	// --------------- Act ---------------
+	err := annotation.PlotDataset(context.Background(), annotation.Dataset{"missing.jpg": nil}, t.TempDir(), t.TempDir())
+
+	// --------------- Assert ---------------
+	assert.Error(t, err)
+}
+
+func TestWriteYOLO(t *testing.T) {
+	// --------------- Arrange ---------------
+	box := mimage.NewBox(mimage.FormatCXCYWH, true, 0.5, 0.5, 0.25, 0.25)
+	plots := []mimage.PlotDataModel{
+		{Rect: image.Rect(0, 0, 99, 49), Class: "person", Score: 0.5},
+		{Box: &box, Class: "3"},
+	}
+
+	// --------------- Act ---------------
+	var buf bytes.Buffer
+	err := annotation.WriteYOLO(&buf, plots, image.Pt(200, 100), []string{"face", "person"})
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.Equal(t, "1 0.250000 0.250000 0.500000 0.500000 0.500000\n3 0.500000 0.500000 0.250000 0.250000\n", buf.String())
+}
+
+func TestWriteYOLOErrors(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Plots         []mimage.PlotDataModel
+		Size          image.Point
+		ExpectedError error
+	}{
+		{
+			Name:          "Unknown class",
+			Plots:         []mimage.PlotDataModel{{Rect: image.Rect(0, 0, 1, 1), Class: "car"}},
+			Size:          image.Pt(10, 10),
+			ExpectedError: annotation.ErrUnknownClass,
+		},
+		{
+			Name:          "Pixel rect without size",
+			Plots:         []mimage.PlotDataModel{{Rect: image.Rect(0, 0, 1, 1), Class: "face"}},
+			ExpectedError: annotation.ErrMissingSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			err := annotation.WriteYOLO(io.Discard, tt.Plots, tt.Size, []string{"face"})
+
+			// --------------- Assert ---------------
+			assert.ErrorIs(t, err, tt.ExpectedError)
+		})
+	}
+}
+
+func TestWriteYOLODir(t *testing.T) {
+	// --------------- Arrange ---------------
+	dir := t.TempDir()
+	ds := annotation.Dataset{"a.jpg": {{Rect: image.Rect(10, 20, 59, 79), Class: "face"}}}
+
+	// --------------- Act ---------------
+	err := annotation.WriteYOLODir(dir, ds, map[string]image.Point{"a.jpg": image.Pt(200, 300)}, []string{"face"})
+	data, readErr := os.ReadFile(filepath.Join(dir, "a.txt"))
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.NoError(t, readErr)
+	assert.Equal(t, "0 0.175000 0.166667 0.250000 0.200000\n", string(data))
+}
+
+func TestWriteYOLODirSubdirectory(t *testing.T) {
+	// --------------- Arrange ---------------
+	dir := t.TempDir()
+	ds := annotation.Dataset{"train/x.jpg": {{Rect: image.Rect(10, 20, 59, 79), Class: "face"}}}
+
+	// --------------- Act ---------------
+	err := annotation.WriteYOLODir(dir, ds, map[string]image.Point{"train/x.jpg": image.Pt(200, 300)}, []string{"face"})
+	_, statErr := os.Stat(filepath.Join(dir, "train", "x.txt"))
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.NoError(t, statErr)
+}
+
+func TestWriteYOLODirInvalidName(t *testing.T) {
+	// --------------- Arrange ---------------
+	root := t.TempDir()
+	ds := annotation.Dataset{"../x.jpg": {{Rect: image.Rect(10, 20, 59, 79), Class: "face"}}}
+
+	// --------------- Act ---------------
+	err := annotation.WriteYOLODir(filepath.Join(root, "labels"), ds, map[string]image.Point{"../x.jpg": image.Pt(200, 300)}, []string{"face"})
+
+	// --------------- Assert ---------------
+	assert.ErrorIs(t, err, annotation.ErrInvalidName)
+	_, statErr := os.Stat(filepath.Join(root, "x.txt"))
+	assert.True(t, os.IsNotExist(statErr))
+}
@@ -0,0 +1,106 @@
+package mimage
+
+import (
+	"image"
+	"math"
+)
+
+// รูปแบบของค่าทั้ง 4 ใน Box
+type BoxFormat int
+
+const (
+	FormatXYXY   BoxFormat = iota // x1, y1, x2, y2 (มุมซ้ายบนและมุมขวาล่าง)
+	FormatXYWH                    // x, y, w, h โดย x, y เป็นมุมซ้ายบน (COCO)
+	FormatCXCYWH                  // cx, cy, w, h โดย cx, cy เป็นจุดกึ่งกลาง (YOLO)
+)
+
+// กรอบในรูปแบบต่าง ๆ ที่ model ส่งออกมา จะถูกแปลงเป็น image.Rectangle ตามขนาดภาพจริงตอนวาด
+// ถ้า Normalized เป็น true ค่าทั้งหมดเป็นสัดส่วน 0-1 ของความกว้าง/สูงของภาพ
+type Box struct {
+	Format     BoxFormat
+	Normalized bool
+	Values     [4]float64
+}
+
+// สำหรับสร้าง Box จากค่า 4 ค่าตาม format
+func NewBox(format BoxFormat, normalized bool, a, b, c, d float64) Box {
+	return Box{Format: format, Normalized: normalized, Values: [4]float64{a, b, c, d}}
+}
+
+// สำหรับสร้าง Box แบบ pixel xyxy จาก rect ที่ Max เป็น pixel สุดท้ายเหมือน PlotDataModel.Rect
+// x2, y2 ของ Box ไม่รวม pixel นั้น จึงบวก 1 ให้แปลงกลับด้วย Rect ได้ค่าเดิม
+func BoxFromRect(r image.Rectangle) Box {
+	return NewBox(FormatXYXY, false, float64(r.Min.X), float64(r.Min.Y), float64(r.Max.X+1), float64(r.Max.Y+1))
+}
+
+// คืนค่า x1, y1, x2, y2 ตามหน่วยเดิมของ Box (pixel หรือสัดส่วน)
+func (b Box) xyxy() (x1, y1, x2, y2 float64) {
+	v := b.Values
+	switch b.Format {
+	case FormatXYWH:
+		return v[0], v[1], v[0] + v[2], v[1] + v[3]
+	case FormatCXCYWH:
+		return v[0] - v[2]/2, v[1] - v[3]/2, v[0] + v[2]/2, v[1] + v[3]/2
+	default:
+		return v[0], v[1], v[2], v[3]
+	}
+}
+
+// สำหรับแปลงเป็น format อื่นโดยยังเป็นหน่วยเดิม (pixel หรือสัดส่วน)
+func (b Box) To(format BoxFormat) Box {
+	x1, y1, x2, y2 := b.xyxy()
+	switch format {
+	case FormatXYWH:
+		return NewBox(format, b.Normalized, x1, y1, x2-x1, y2-y1)
+	case FormatCXCYWH:
+		return NewBox(format, b.Normalized, (x1+x2)/2, (y1+y2)/2, x2-x1, y2-y1)
+	default:
+		return NewBox(FormatXYXY, b.Normalized, x1, y1, x2, y2)
+	}
+}
+
+// สำหรับแปลงค่า pixel เป็นสัดส่วนของภาพขนาด size
+func (b Box) Normalize(size image.Point) Box {
+	if b.Normalized {
+		return b
+	}
+	return b.scale(1/float64(size.X), 1/float64(size.Y), true)
+}
+
+// สำหรับแปลงค่าสัดส่วนเป็น pixel ของภาพขนาด size
+func (b Box) Denormalize(size image.Point) Box {
+	if !b.Normalized {
+		return b
+	}
+	return b.scale(float64(size.X), float64(size.Y), false)
+}
+
+func (b Box) scale(sx, sy float64, normalized bool) Box {
+	v := b.Values
+	return NewBox(b.Format, normalized, v[0]*sx, v[1]*sy, v[2]*sx, v[3]*sy)
+}
+
+// สำหรับแปลงเป็น image.Rectangle บนภาพที่มีขอบเขต bounds โดยปัดเศษเป็น pixel ที่ใกล้ที่สุด
+// x2, y2 ของ Box ไม่รวม pixel นั้น แต่ Max ของผลลัพธ์เป็น pixel สุดท้ายเหมือน PlotDataModel.Rect
+// เช่น xywh 10, 10, 20, 20 จะได้ (10,10)-(29,29)
+func (b Box) Rect(bounds image.Rectangle) image.Rectangle {
+	x1, y1, x2, y2 := b.Denormalize(bounds.Size()).xyxy()
+	r := image.Rect(int(math.Round(x1)), int(math.Round(y1)), int(math.Round(x2)), int(math.Round(y2)))
+	r.Max = image.Pt(max(r.Max.X-1, r.Min.X), max(r.Max.Y-1, r.Min.Y))
+	return r.Add(bounds.Min)
+}
+
+// แทนค่า Rect ด้วย Shape หรือ Box ของกรอบที่กำหนดไว้ โดยไม่แก้ไข slice ต้นฉบับ
+func resolveBoxes(bounds image.Rectangle, plots []PlotDataModel) []PlotDataModel {
+	resolved := make([]PlotDataModel, len(plots))
+	for i, p := range plots {
+		switch {
+		case p.Shape != nil:
+			p.Rect = p.Shape.Bounds()
+		case p.Box != nil:
+			p.Rect = p.Box.Rect(bounds)
+		}
+		resolved[i] = p
+	}
+	return resolved
+}
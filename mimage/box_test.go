@@ -0,0 +1,121 @@
+package mimage_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoxRect(t *testing.T) {
+	bounds := image.Rect(0, 0, 640, 480)
+	tests := []struct {
+		Name     string
+		Input    mimage.Box
+		Expected image.Rectangle
+	}{
+		{
+			Name:     "Pixel xyxy",
+			Input:    mimage.NewBox(mimage.FormatXYXY, false, 10, 20, 110, 220),
+			Expected: image.Rect(10, 20, 109, 219),
+		},
+		{
+			Name:     "Pixel xywh (COCO)",
+			Input:    mimage.NewBox(mimage.FormatXYWH, false, 10, 20, 100, 200),
+			Expected: image.Rect(10, 20, 109, 219),
+		},
+		{
+			Name:     "Pixel cxcywh",
+			Input:    mimage.NewBox(mimage.FormatCXCYWH, false, 60, 120, 100, 200),
+			Expected: image.Rect(10, 20, 109, 219),
+		},
+		{
+			Name:     "Normalized cxcywh (YOLO)",
+			Input:    mimage.NewBox(mimage.FormatCXCYWH, true, 0.5, 0.5, 0.25, 0.5),
+			Expected: image.Rect(240, 120, 399, 359),
+		},
+		{
+			Name:     "Normalized xyxy",
+			Input:    mimage.NewBox(mimage.FormatXYXY, true, 0, 0, 1, 1),
+			Expected: image.Rect(0, 0, 639, 479),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result := tt.Input.Rect(bounds)
+
+			// --------------- Assert ---------------
+			assert.Equal(t, tt.Expected, result)
+		})
+	}
+}
+
+func TestBoxConvert(t *testing.T) {
+	// --------------- Arrange ---------------
+	size := image.Pt(200, 100)
+	box := mimage.NewBox(mimage.FormatXYWH, false, 20, 10, 40, 60)
+
+	// --------------- Act ---------------
+	xyxy := box.To(mimage.FormatXYXY)
+	cxcywh := box.To(mimage.FormatCXCYWH)
+	normalized := cxcywh.Normalize(size)
+	back := normalized.Denormalize(size).To(mimage.FormatXYWH)
+
+	// --------------- Assert ---------------
+	assert.Equal(t, [4]float64{20, 10, 60, 70}, xyxy.Values)
+	assert.Equal(t, [4]float64{40, 40, 40, 60}, cxcywh.Values)
+	assert.True(t, normalized.Normalized)
+	assert.InDeltaSlice(t, []float64{0.2, 0.4, 0.2, 0.6}, normalized.Values[:], 1e-9)
+	assert.Equal(t, mimage.FormatXYWH, back.Format)
+	assert.InDeltaSlice(t, box.Values[:], back.Values[:], 1e-9)
+	assert.Equal(t, image.Rect(1, 2, 3, 4), mimage.BoxFromRect(image.Rect(1, 2, 3, 4)).Rect(image.Rect(0, 0, 10, 10)))
+}
+
+func TestPlotImageWithBox(t *testing.T) {
+	// --------------- Arrange ---------------
+	box := mimage.NewBox(mimage.FormatCXCYWH, true, 0.5, 0.5, 0.5, 0.5)
+	plotData := []mimage.PlotDataModel{{Box: &box}}
+
+	// --------------- Act ---------------
+	result, err := mimage.PlotImageFromBytes(createTestImage("png"), plotData)
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	img, _, _ := image.Decode(bytes.NewReader(result))
+	assert.Equal(t, color.RGBA{255, 0, 0, 255}, color.RGBAModel.Convert(img.At(50, 100)))
+	assert.Equal(t, color.RGBA{255, 0, 0, 255}, color.RGBAModel.Convert(img.At(149, 100)))
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, color.RGBAModel.Convert(img.At(100, 100)))
+	assert.Equal(t, image.Rectangle{}, plotData[0].Rect)
+}
+
+func TestPlotImageWithBoxEdge(t *testing.T) {
+	// --------------- Arrange ---------------
+	box := mimage.NewBox(mimage.FormatXYWH, false, 10, 10, 20, 20)
+	style := mimage.PlotStyle{Thickness: 1}
+
+	// --------------- Act ---------------
+	result, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{{Box: &box, Style: style}})
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	img, _, _ := image.Decode(bytes.NewReader(result))
+	assert.Equal(t, color.RGBA{255, 0, 0, 255}, color.RGBAModel.Convert(img.At(29, 20)))
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, color.RGBAModel.Convert(img.At(30, 20)))
+}
+
+func TestPlotImageWithFullImageBoxAndStrict(t *testing.T) {
+	// --------------- Arrange ---------------
+	box := mimage.NewBox(mimage.FormatCXCYWH, true, 0.5, 0.5, 1, 1)
+
+	// --------------- Act ---------------
+	result, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{{Box: &box}}, mimage.WithStrict())
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	img, _, _ := image.Decode(bytes.NewReader(result))
+	assert.Equal(t, color.RGBA{255, 0, 0, 255}, color.RGBAModel.Convert(img.At(199, 100)))
+}
@@ -0,0 +1,255 @@
+package mimage
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// ขนาดรวมสูงสุดของ cache เมื่อไม่ได้กำหนด CachingFetcher.MaxBytes
+	DefaultCacheMaxBytes int64 = 64 << 20
+	// อายุของข้อมูลใน cache ก่อนต้อง revalidate เมื่อไม่ได้กำหนด CachingFetcher.TTL
+	DefaultCacheTTL = 5 * time.Minute
+)
+
+// สำหรับกำหนดการ retry แบบ exponential backoff เมื่อเจอ 5xx, 429 หรือ timeout
+type RetryPolicy struct {
+	MaxAttempts int           // จำนวนครั้งที่ลองทั้งหมดรวมครั้งแรก (default: 1 คือไม่ retry)
+	BaseDelay   time.Duration // ระยะรอก่อน retry ครั้งแรก และจะเพิ่มเป็น 2 เท่าทุกครั้ง (default: 100ms)
+	MaxDelay    time.Duration // ระยะรอสูงสุดต่อครั้ง (default: 5s)
+}
+
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	base, max := p.BaseDelay, p.MaxDelay
+	if base <= 0 {
+		base = 100 * time.Millisecond
+	}
+	if max <= 0 {
+		max = 5 * time.Second
+	}
+
+	d := base
+	for i := 0; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
+
+// สำหรับดึงภาพผ่าน http พร้อม LRU cache (จำกัดตามขนาด byte), TTL, revalidate ด้วย ETag/Last-Modified และ retry
+// zero value พร้อมใช้งาน และปลอดภัยเมื่อเรียกใช้จากหลาย goroutine พร้อมกัน
+// []byte ที่คืนค่าไปเป็นข้อมูลเดียวกับใน cache ห้ามแก้ไข
+type CachingFetcher struct {
+	HTTP     *HTTPFetcher  // fetcher ที่ใช้ดึงข้อมูลจริง (default: &HTTPFetcher{})
+	MaxBytes int64         // default: DefaultCacheMaxBytes
+	TTL      time.Duration // default: DefaultCacheTTL
+	Retry    RetryPolicy
+
+	mu       sync.Mutex
+	size     int64
+	lru      *list.List // element ล่าสุดที่ใช้อยู่ด้านหน้า
+	entries  map[string]*list.Element
+	inflight map[string]*fetchCall
+}
+
+type cacheEntry struct {
+	url          string
+	data         []byte
+	etag         string
+	lastModified string
+	fetchedAt    time.Time
+}
+
+type fetchCall struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+func (f *CachingFetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	f.mu.Lock()
+	if f.entries == nil {
+		f.lru = list.New()
+		f.entries = make(map[string]*list.Element)
+		f.inflight = make(map[string]*fetchCall)
+	}
+	if el, ok := f.entries[url]; ok {
+		e := el.Value.(*cacheEntry)
+		if time.Since(e.fetchedAt) < f.ttl() {
+			f.lru.MoveToFront(el)
+			f.mu.Unlock()
+			return e.data, nil
+		}
+	}
+
+	// ถ้ามี goroutine อื่นกำลังดึง url เดียวกันอยู่ ให้รอผลลัพธ์จากตัวนั้นแทน
+	c, ok := f.inflight[url]
+	if !ok {
+		c = &fetchCall{done: make(chan struct{})}
+		f.inflight[url] = c
+		// ผลลัพธ์ถูกใช้ร่วมกันทุกคนที่รอ จึงไม่ยกเลิกตาม ctx ของผู้เรียกคนแรก
+		// (ยังจำกัดเวลาด้วย timeout ของ http.Client)
+		go func() {
+			c.data, c.err = f.load(context.WithoutCancel(ctx), url)
+
+			f.mu.Lock()
+			delete(f.inflight, url)
+			f.mu.Unlock()
+			close(c.done)
+		}()
+	}
+	f.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.data, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (f *CachingFetcher) load(ctx context.Context, url string) ([]byte, error) {
+	f.mu.Lock()
+	var stale *cacheEntry
+	if el, ok := f.entries[url]; ok {
+		stale = el.Value.(*cacheEntry)
+	}
+	f.mu.Unlock()
+
+	header := http.Header{}
+	if stale != nil {
+		if stale.etag != "" {
+			header.Set("If-None-Match", stale.etag)
+		}
+		if stale.lastModified != "" {
+			header.Set("If-Modified-Since", stale.lastModified)
+		}
+	}
+
+	res, err := f.fetchWithRetry(ctx, url, header)
+	if err != nil {
+		return nil, err
+	}
+	if res.statusCode == http.StatusNotModified {
+		if stale == nil {
+			return nil, &HTTPStatusError{URL: url, StatusCode: res.statusCode}
+		}
+		res.data = stale.data
+		if res.etag == "" {
+			res.etag = stale.etag
+		}
+		if res.lastModified == "" {
+			res.lastModified = stale.lastModified
+		}
+	}
+
+	f.store(&cacheEntry{
+		url:          url,
+		data:         res.data,
+		etag:         res.etag,
+		lastModified: res.lastModified,
+		fetchedAt:    time.Now(),
+	})
+
+	return res.data, nil
+}
+
+func (f *CachingFetcher) fetchWithRetry(ctx context.Context, url string, header http.Header) (*fetchResult, error) {
+	fetcher := f.HTTP
+	if fetcher == nil {
+		fetcher = &HTTPFetcher{}
+	}
+	attempts := f.Retry.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	for attempt := 0; ; attempt++ {
+		res, err := fetcher.fetch(ctx, url, header)
+		if err == nil || attempt+1 >= attempts || !retryable(ctx, err) {
+			return res, err
+		}
+
+		timer := time.NewTimer(f.Retry.delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retry เฉพาะ error ชั่วคราว คือ 5xx, 429 และ timeout ที่ไม่ได้มาจาก ctx ของผู้เรียก
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500 || statusErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+func (f *CachingFetcher) store(e *cacheEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if el, ok := f.entries[e.url]; ok {
+		f.size -= int64(len(el.Value.(*cacheEntry).data))
+		f.lru.Remove(el)
+		delete(f.entries, e.url)
+	}
+
+	maxBytes := f.maxBytes()
+	if int64(len(e.data)) > maxBytes {
+		return
+	}
+
+	f.entries[e.url] = f.lru.PushFront(e)
+	f.size += int64(len(e.data))
+
+	// ลบ entry ที่ไม่ได้ใช้นานที่สุดออกจนกว่าขนาดรวมจะไม่เกิน MaxBytes
+	for f.size > maxBytes {
+		el := f.lru.Back()
+		old := el.Value.(*cacheEntry)
+		f.size -= int64(len(old.data))
+		f.lru.Remove(el)
+		delete(f.entries, old.url)
+	}
+}
+
+func (f *CachingFetcher) ttl() time.Duration {
+	if f.TTL <= 0 {
+		return DefaultCacheTTL
+	}
+	return f.TTL
+}
+
+func (f *CachingFetcher) maxBytes() int64 {
+	if f.MaxBytes <= 0 {
+		return DefaultCacheMaxBytes
+	}
+	return f.MaxBytes
+}
+
+// จำนวน byte ที่อยู่ใน cache ตอนนี้
+func (f *CachingFetcher) Size() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.size
+}
@@ -0,0 +1,197 @@
+package mimage_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachingFetcher(t *testing.T) {
+	pngData := createTestImage("png")
+	var hits, notModified, failures atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/image/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		hits.Add(1)
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("ETag", `"v1"`)
+		time.Sleep(10 * time.Millisecond)
+		w.Write(pngData)
+	})
+	mux.HandleFunc("/flaky", func(w http.ResponseWriter, r *http.Request) {
+		if failures.Add(1) <= 2 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(pngData)
+	})
+	started, release := make(chan struct{}), make(chan struct{})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.Write(pngData)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Run("Serve from cache", func(t *testing.T) {
+		// --------------- Arrange ---------------
+		hits.Store(0)
+		f := &mimage.CachingFetcher{}
+
+		// --------------- Act ---------------
+		first, err1 := f.Fetch(context.Background(), server.URL+"/image/a.png")
+		second, err2 := f.Fetch(context.Background(), server.URL+"/image/a.png")
+
+		// --------------- Assert ---------------
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Equal(t, pngData, first)
+		assert.Equal(t, first, second)
+		assert.Equal(t, int32(1), hits.Load())
+		assert.Equal(t, int64(len(pngData)), f.Size())
+	})
+
+	t.Run("Revalidate with ETag after TTL", func(t *testing.T) {
+		// --------------- Arrange ---------------
+		hits.Store(0)
+		notModified.Store(0)
+		f := &mimage.CachingFetcher{TTL: 20 * time.Millisecond}
+
+		// --------------- Act ---------------
+		_, err := f.Fetch(context.Background(), server.URL+"/image/b.png")
+		time.Sleep(30 * time.Millisecond)
+		result, err2 := f.Fetch(context.Background(), server.URL+"/image/b.png")
+
+		// --------------- Assert ---------------
+		assert.NoError(t, err)
+		assert.NoError(t, err2)
+		assert.Equal(t, pngData, result)
+		assert.Equal(t, int32(1), hits.Load())
+		assert.Equal(t, int32(1), notModified.Load())
+	})
+
+	t.Run("Evict least recently used", func(t *testing.T) {
+		// --------------- Arrange ---------------
+		hits.Store(0)
+		f := &mimage.CachingFetcher{MaxBytes: int64(len(pngData) * 2)}
+
+		// --------------- Act ---------------
+		for _, name := range []string{"a", "b", "a", "c", "a", "b"} {
+			_, err := f.Fetch(context.Background(), server.URL+"/image/"+name)
+			assert.NoError(t, err)
+		}
+
+		// --------------- Assert ---------------
+		// a, b, c ถูกโหลดครั้งแรก และ b ถูก evict ตอนโหลด c จึงต้องโหลดใหม่
+		assert.Equal(t, int32(4), hits.Load())
+		assert.Equal(t, int64(len(pngData)*2), f.Size())
+	})
+
+	t.Run("Retry on 5xx", func(t *testing.T) {
+		// --------------- Arrange ---------------
+		failures.Store(0)
+		f := &mimage.CachingFetcher{Retry: mimage.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}}
+
+		// --------------- Act ---------------
+		result, err := f.Fetch(context.Background(), server.URL+"/flaky")
+
+		// --------------- Assert ---------------
+		assert.NoError(t, err)
+		assert.Equal(t, pngData, result)
+		assert.Equal(t, int32(3), failures.Load())
+	})
+
+	t.Run("Give up after max attempts", func(t *testing.T) {
+		// --------------- Arrange ---------------
+		failures.Store(0)
+		f := &mimage.CachingFetcher{Retry: mimage.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}}
+
+		// --------------- Act ---------------
+		_, err := f.Fetch(context.Background(), server.URL+"/flaky")
+
+		// --------------- Assert ---------------
+		assert.ErrorIs(t, err, mimage.ErrHTTPStatus)
+		assert.Equal(t, int32(2), failures.Load())
+	})
+
+	t.Run("Do not retry 4xx", func(t *testing.T) {
+		// --------------- Arrange ---------------
+		f := &mimage.CachingFetcher{Retry: mimage.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}}
+
+		// --------------- Act ---------------
+		start := time.Now()
+		_, err := f.Fetch(context.Background(), server.URL+"/missing")
+
+		// --------------- Assert ---------------
+		assert.ErrorIs(t, err, mimage.ErrHTTPStatus)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("Concurrent fetches share one request", func(t *testing.T) {
+		// --------------- Arrange ---------------
+		hits.Store(0)
+		f := &mimage.CachingFetcher{}
+		wg := new(sync.WaitGroup)
+
+		// --------------- Act ---------------
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				src := mimage.URLSource{URL: server.URL + "/image/shared.png", Fetcher: f}
+				_, _, err := mimage.PlotImage(context.Background(), src, nil)
+				assert.NoError(t, err, fmt.Sprint("goroutine ", i))
+			}(i)
+		}
+		wg.Wait()
+
+		// --------------- Assert ---------------
+		assert.Equal(t, int32(1), hits.Load())
+	})
+
+	t.Run("Waiter is not cancelled with the first caller", func(t *testing.T) {
+		// --------------- Arrange ---------------
+		f := &mimage.CachingFetcher{}
+		ctx, cancel := context.WithCancel(context.Background())
+		firstErr := make(chan error, 1)
+		go func() {
+			_, err := f.Fetch(ctx, server.URL+"/slow")
+			firstErr <- err
+		}()
+		<-started
+
+		type result struct {
+			data []byte
+			err  error
+		}
+		second := make(chan result, 1)
+		go func() {
+			data, err := f.Fetch(context.Background(), server.URL+"/slow")
+			second <- result{data, err}
+		}()
+
+		// --------------- Act ---------------
+		cancel()
+		err := <-firstErr
+		close(release)
+		res := <-second
+
+		// --------------- Assert ---------------
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NoError(t, res.err)
+		assert.Equal(t, pngData, res.data)
+	})
+}
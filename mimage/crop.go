@@ -0,0 +1,90 @@
+package mimage
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// กำหนดวิธีตัดภาพของ CropRegions field ไหนที่เป็น zero value จะใช้ค่า default
+type CropOptions struct {
+	Padding float64     // ขยายกรอบออกทุกด้านเป็นสัดส่วนของขนาดกรอบ เช่น 0.2 คือ 20%
+	Square  bool        // ขยายด้านที่สั้นกว่าให้เท่าด้านที่ยาวกว่าโดยจุดกึ่งกลางเดิม
+	Fill    color.Color // สีที่เติมส่วนที่เกินขอบภาพ (default: nil คือตัดให้อยู่ในภาพ)
+	Size    image.Point // ขนาดของภาพผลลัพธ์ (default: ขนาดเดิมของส่วนที่ตัด) ถ้าสัดส่วนไม่ตรงจะถูกยืด
+}
+
+// สำหรับตัดพื้นที่ของแต่ละกรอบใน plotData ออกมาเป็นภาพ encode แยกกัน เช่นภาพใบหน้าสำหรับ recognition
+// ผลลัพธ์เรียงตาม plotData เสมอ กรอบที่ไม่เหลือพื้นที่ในภาพ (เมื่อไม่ได้กำหนด Fill) หรือไม่มีพื้นที่เลยจะเป็น nil
+// กรอบที่มีแต่ Mask จะตัดตามขอบเขตของ mask
+// opts ใช้กำหนดการ encode เท่านั้น (WithFormat, WithJPEGQuality, WithPNGCompression)
+func CropRegions(ctx context.Context, src Source, plotData []PlotDataModel, c CropOptions, opts ...Option) (crops [][]byte, format string, err error) {
+	o := newOptions(opts)
+	if err := o.validate(); err != nil {
+		return nil, "", err
+	}
+
+	img, t, err := LoadImage(ctx, src)
+	if err != nil {
+		return nil, "", err
+	}
+
+	plotData = resolveBoxes(img.Bounds(), plotData)
+	crops = make([][]byte, len(plotData))
+	format = o.outputFormat(t)
+	for i, p := range plotData {
+		area, ok := plotArea(p, img.Bounds())
+		if !ok {
+			continue
+		}
+		crop := cropRect(img, area, c)
+		if crop == nil {
+			continue
+		}
+
+		buf := new(bytes.Buffer)
+		if format, err = encodeImage(buf, crop, t, o); err != nil {
+			return nil, "", err
+		}
+		crops[i] = buf.Bytes()
+	}
+
+	return crops, format, nil
+}
+
+// ตัดพื้นที่ rect จาก img ตาม c คืนค่า nil ถ้าไม่มีพื้นที่เหลือ
+func cropRect(img *image.RGBA, rect image.Rectangle, c CropOptions) *image.RGBA {
+	if c.Padding > 0 {
+		pad := image.Pt(int(math.Round(float64(rect.Dx())*c.Padding)), int(math.Round(float64(rect.Dy())*c.Padding)))
+		rect = image.Rectangle{Min: rect.Min.Sub(pad), Max: rect.Max.Add(pad)}
+	}
+	if c.Square {
+		side := max(rect.Dx(), rect.Dy())
+		min := image.Pt(rect.Min.X-(side-rect.Dx())/2, rect.Min.Y-(side-rect.Dy())/2)
+		rect = image.Rectangle{Min: min, Max: min.Add(image.Pt(side, side))}
+	}
+	if c.Fill == nil {
+		rect = rect.Intersect(img.Bounds())
+	}
+	if rect.Empty() {
+		return nil
+	}
+
+	crop := image.NewRGBA(image.Rectangle{Max: rect.Size()})
+	if c.Fill != nil {
+		draw.Draw(crop, crop.Bounds(), image.NewUniform(c.Fill), image.Point{}, draw.Src)
+	}
+	draw.Draw(crop, crop.Bounds(), img, rect.Min, draw.Src)
+
+	if c.Size.X <= 0 || c.Size.Y <= 0 || c.Size == crop.Bounds().Size() {
+		return crop
+	}
+	scaled := image.NewRGBA(image.Rectangle{Max: c.Size})
+	xdraw.CatmullRom.Scale(scaled, scaled.Bounds(), crop, crop.Bounds(), draw.Src, nil)
+	return scaled
+}
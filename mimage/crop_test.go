@@ -0,0 +1,147 @@
+package mimage_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCropRegions(t *testing.T) {
+	orig, data := createCheckerImage()
+	tests := []struct {
+		Name     string
+		Rect     image.Rectangle
+		Options  mimage.CropOptions
+		Expected image.Rectangle // พื้นที่บนภาพต้นฉบับที่ควรได้
+		Fill     []image.Point   // pixel บนภาพที่ตัดแล้วที่ต้องเป็นสี Fill
+	}{
+		{
+			Name:     "Plain crop includes the Max pixel",
+			Rect:     image.Rect(10, 20, 59, 49),
+			Expected: image.Rect(10, 20, 60, 50),
+		},
+		{
+			Name:     "Padding",
+			Rect:     image.Rect(50, 50, 149, 99),
+			Options:  mimage.CropOptions{Padding: 0.1},
+			Expected: image.Rect(40, 45, 160, 105),
+		},
+		{
+			Name:     "Square grows the short side",
+			Rect:     image.Rect(50, 80, 149, 119),
+			Options:  mimage.CropOptions{Square: true},
+			Expected: image.Rect(50, 50, 150, 150),
+		},
+		{
+			Name:     "Clamp to bounds",
+			Rect:     image.Rect(-20, 150, 49, 249),
+			Expected: image.Rect(0, 150, 50, 200),
+		},
+		{
+			Name:     "Fill outside bounds",
+			Rect:     image.Rect(-20, 150, 49, 249),
+			Options:  mimage.CropOptions{Fill: color.RGBA{0, 0, 255, 255}},
+			Expected: image.Rect(-20, 150, 50, 250),
+			Fill:     []image.Point{{0, 0}, {19, 0}, {50, 60}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			crops, format, err := mimage.CropRegions(context.Background(), mimage.BytesSource{Data: data}, []mimage.PlotDataModel{
+				{Rect: tt.Rect},
+			}, tt.Options)
+
+			// --------------- Assert ---------------
+			assert.NoError(t, err)
+			assert.Equal(t, "png", format)
+			assert.Len(t, crops, 1)
+			img, _, err := image.Decode(bytes.NewReader(crops[0]))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.Expected.Size(), img.Bounds().Size())
+			for _, p := range tt.Fill {
+				assert.Equal(t, color.RGBA{0, 0, 255, 255}, color.RGBAModel.Convert(img.At(p.X, p.Y)), "fill pixel %v", p)
+			}
+			for y := 0; y < img.Bounds().Dy(); y++ {
+				for x := 0; x < img.Bounds().Dx(); x++ {
+					src := tt.Expected.Min.Add(image.Pt(x, y))
+					if src.In(orig.Bounds()) {
+						assert.Equal(t, orig.At(src.X, src.Y), color.RGBAModel.Convert(img.At(x, y)), "pixel %d,%d", x, y)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCropRegionsResizeAndOrder(t *testing.T) {
+	_, data := createCheckerImage()
+
+	// --------------- Act ---------------
+	crops, format, err := mimage.CropRegions(context.Background(), mimage.BytesSource{Data: data}, []mimage.PlotDataModel{
+		{Rect: image.Rect(10, 10, 89, 49)},
+		{Rect: image.Rect(300, 300, 400, 400)},
+		{Box: &mimage.Box{Format: mimage.FormatCXCYWH, Normalized: true, Values: [4]float64{0.5, 0.5, 0.2, 0.2}}},
+	}, mimage.CropOptions{Square: true, Size: image.Pt(112, 112)}, mimage.WithFormat("jpg"))
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Len(t, crops, 3)
+	assert.Nil(t, crops[1])
+	for _, i := range []int{0, 2} {
+		img, f, err := image.Decode(bytes.NewReader(crops[i]))
+		assert.NoError(t, err)
+		assert.Equal(t, "jpeg", f)
+		assert.Equal(t, image.Rect(0, 0, 112, 112), img.Bounds())
+	}
+}
+
+func TestCropRegionsMaskAndEmptyPlots(t *testing.T) {
+	// --------------- Arrange ---------------
+	_, data := createCheckerImage()
+	mask := image.NewGray(image.Rect(0, 0, 4, 4))
+	mask.SetGray(2, 1, color.Gray{Y: 1})
+	hidden := mimage.Keypoints{Points: []mimage.Keypoint{{At: image.Pt(5, 5), Hidden: true}}}
+
+	// --------------- Act ---------------
+	crops, _, err := mimage.CropRegions(context.Background(), mimage.BytesSource{Data: data}, []mimage.PlotDataModel{
+		{},
+		{Shape: hidden},
+		{Mask: &mimage.Mask{Data: mask, Rect: image.Rect(100, 100, 200, 200)}},
+	}, mimage.CropOptions{})
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.Len(t, crops, 3)
+	assert.Nil(t, crops[0])
+	assert.Nil(t, crops[1])
+	img, _, err := image.Decode(bytes.NewReader(crops[2]))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 25, 25), img.Bounds())
+}
+
+func TestCropRegionsFormatWithoutCrops(t *testing.T) {
+	// --------------- Act ---------------
+	crops, format, err := mimage.CropRegions(context.Background(), mimage.BytesSource{Data: createTestImage("png")}, []mimage.PlotDataModel{
+		{Rect: image.Rect(300, 300, 400, 400)},
+	}, mimage.CropOptions{}, mimage.WithFormat("jpeg"))
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{nil}, crops)
+	assert.Equal(t, "jpeg", format)
+}
+
+func TestCropRegionsUnsupportedFormat(t *testing.T) {
+	// --------------- Act ---------------
+	_, _, err := mimage.CropRegions(context.Background(), mimage.BytesSource{Data: createTestImage("png")}, nil, mimage.CropOptions{}, mimage.WithFormat("gif"))
+
+	// --------------- Assert ---------------
+	assert.ErrorIs(t, err, mimage.ErrUnsupportedFormat)
+}
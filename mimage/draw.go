@@ -0,0 +1,200 @@
+package mimage
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"sort"
+)
+
+// วาดเส้นกรอบความหนา thickness ไว้ด้านในของ r โดยแบ่งเป็นสี่เหลี่ยมทึบ 4 ชิ้นที่ไม่ทับกัน
+// เพื่อให้สีที่โปร่งแสงไม่ถูก blend ซ้ำตรงมุม
+func strokeRect(dst draw.Image, r image.Rectangle, thickness int, c color.Color) {
+	if r.Empty() || thickness <= 0 {
+		return
+	}
+
+	top := image.Rectangle{Min: r.Min, Max: image.Pt(r.Max.X, min(r.Min.Y+thickness, r.Max.Y))}
+	bottom := image.Rectangle{Min: image.Pt(r.Min.X, max(r.Max.Y-thickness, top.Max.Y)), Max: r.Max}
+	left := image.Rectangle{Min: image.Pt(r.Min.X, top.Max.Y), Max: image.Pt(min(r.Min.X+thickness, r.Max.X), bottom.Min.Y)}
+	right := image.Rectangle{Min: image.Pt(max(r.Max.X-thickness, left.Max.X), top.Max.Y), Max: image.Pt(r.Max.X, bottom.Min.Y)}
+
+	for _, part := range []image.Rectangle{top, bottom, left, right} {
+		fillRect(dst, part, c)
+	}
+}
+
+// ระบายสี่เหลี่ยมทึบด้วยสี c แบบ source-over
+// ถ้า dst เป็น *image.RGBA จะเขียนลง Pix โดยตรง นอกนั้นใช้ draw.Draw
+func fillRect(dst draw.Image, r image.Rectangle, c color.Color) {
+	r = r.Intersect(dst.Bounds())
+	if r.Empty() {
+		return
+	}
+
+	img, ok := dst.(*image.RGBA)
+	if !ok {
+		draw.Draw(dst, r, image.NewUniform(c), image.Point{}, draw.Over)
+		return
+	}
+
+	sr, sg, sb, sa := c.RGBA()
+	if sa == 0 {
+		return
+	}
+
+	width := r.Dx() * 4
+	start := img.PixOffset(r.Min.X, r.Min.Y)
+
+	// สีทึบ: เขียนแถวแรกแล้ว copy ไปแถวที่เหลือ
+	if sa == 0xffff {
+		row := img.Pix[start : start+width]
+		for i := 0; i < width; i += 4 {
+			row[i+0] = uint8(sr >> 8)
+			row[i+1] = uint8(sg >> 8)
+			row[i+2] = uint8(sb >> 8)
+			row[i+3] = 0xff
+		}
+		for y, i := r.Min.Y+1, start+img.Stride; y < r.Max.Y; y, i = y+1, i+img.Stride {
+			copy(img.Pix[i:i+width], row)
+		}
+		return
+	}
+
+	// สีโปร่งแสง: dst = src + dst*(1-src.alpha) แบบเดียวกับ draw.Over
+	const m = 1<<16 - 1
+	a := (m - sa) * 0x101
+	for y, i := r.Min.Y, start; y < r.Max.Y; y, i = y+1, i+img.Stride {
+		row := img.Pix[i : i+width]
+		for j := 0; j < width; j += 4 {
+			row[j+0] = uint8((uint32(row[j+0])*a/m + sr) >> 8)
+			row[j+1] = uint8((uint32(row[j+1])*a/m + sg) >> 8)
+			row[j+2] = uint8((uint32(row[j+2])*a/m + sb) >> 8)
+			row[j+3] = uint8((uint32(row[j+3])*a/m + sa) >> 8)
+		}
+	}
+}
+
+// วาดเส้นตรงจาก p0 ถึง p1 (รวมปลายทั้งสองด้าน) ด้วย Bresenham โดยแต่ละจุดเป็นสี่เหลี่ยมขนาด thickness
+func drawLine(dst draw.Image, p0, p1 image.Point, thickness int, c color.Color) {
+	if thickness <= 0 {
+		return
+	}
+	half := thickness / 2
+
+	dx, dy := abs(p1.X-p0.X), -abs(p1.Y-p0.Y)
+	sx, sy := sign(p1.X-p0.X), sign(p1.Y-p0.Y)
+	err := dx + dy
+	x, y := p0.X, p0.Y
+	for {
+		fillRect(dst, image.Rect(x-half, y-half, x-half+thickness, y-half+thickness), c)
+		if x == p1.X && y == p1.Y {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+	}
+}
+
+// วาดเส้นต่อกันผ่านทุกจุดใน points ถ้า closed จะวาดเส้นจากจุดสุดท้ายกลับไปจุดแรกด้วย
+func drawPolyline(dst draw.Image, points []image.Point, closed bool, thickness int, c color.Color) {
+	if len(points) == 1 {
+		drawLine(dst, points[0], points[0], thickness, c)
+		return
+	}
+	for i := 1; i < len(points); i++ {
+		drawLine(dst, points[i-1], points[i], thickness, c)
+	}
+	if closed && len(points) > 2 {
+		drawLine(dst, points[len(points)-1], points[0], thickness, c)
+	}
+}
+
+// ระบายพื้นในของ polygon ด้วย scanline แบบ even-odd โดยถือว่าพิกัดของแต่ละจุดคือจุดกึ่งกลางของ pixel
+// แต่ละแถวระบายเป็นช่วงที่ไม่ทับกัน สีโปร่งแสงจึงไม่ถูก blend ซ้ำ
+func fillPolygon(dst draw.Image, points []image.Point, c color.Color) {
+	if len(points) < 3 {
+		return
+	}
+
+	bounds := pointsBounds(points).Intersect(dst.Bounds())
+	xs := make([]float64, 0, len(points))
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		xs = xs[:0]
+		for i := range points {
+			a, b := points[i], points[(i+1)%len(points)]
+			if (a.Y <= y) != (b.Y <= y) {
+				xs = append(xs, float64(a.X)+float64(y-a.Y)*float64(b.X-a.X)/float64(b.Y-a.Y))
+			}
+		}
+		sort.Float64s(xs)
+		for i := 0; i+1 < len(xs); i += 2 {
+			x0, x1 := int(math.Ceil(xs[i])), int(math.Floor(xs[i+1]))
+			fillRect(dst, image.Rect(x0, y, x1+1, y+1), c)
+		}
+	}
+}
+
+// วาดวงกลมรัศมี r รอบ center แบบเส้นขอบความหนา thickness ที่อยู่ด้านในของวงกลม
+// ถ้า thickness >= r จะได้วงกลมทึบ
+func drawRing(dst draw.Image, center image.Point, r, thickness int, c color.Color) {
+	if r < 0 || thickness <= 0 {
+		return
+	}
+
+	inner := r - thickness
+	for dy := -r; dy <= r; dy++ {
+		outer := halfChord(r, dy)
+		y := center.Y + dy
+		if inner < 0 || abs(dy) > inner {
+			fillRect(dst, image.Rect(center.X-outer, y, center.X+outer+1, y+1), c)
+			continue
+		}
+		hole := halfChord(inner, dy)
+		fillRect(dst, image.Rect(center.X-outer, y, center.X-hole, y+1), c)
+		fillRect(dst, image.Rect(center.X+hole+1, y, center.X+outer+1, y+1), c)
+	}
+}
+
+// ครึ่งหนึ่งของความยาวคอร์ดที่ระยะ dy จากจุดศูนย์กลางของวงกลมรัศมี r
+func halfChord(r, dy int) int {
+	return int(math.Sqrt(float64(r*r - dy*dy)))
+}
+
+// ขอบเขตที่ครอบทุกจุด โดย Max คือจุดที่อยู่ขวาล่างสุด (ไม่ +1)
+func pointsBounds(points []image.Point) image.Rectangle {
+	if len(points) == 0 {
+		return image.Rectangle{}
+	}
+	r := image.Rectangle{Min: points[0], Max: points[0]}
+	for _, p := range points[1:] {
+		r.Min.X, r.Min.Y = min(r.Min.X, p.X), min(r.Min.Y, p.Y)
+		r.Max.X, r.Max.Y = max(r.Max.X, p.X), max(r.Max.Y, p.Y)
+	}
+	return r
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,233 @@
+package mimage
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// วิธีวาดแบบเดิม (goroutine ต่อ pixel) เก็บไว้เทียบผลลัพธ์และ benchmark
+func drawRectangleLegacy(img draw.Image, color color.Color, x1, y1, x2, y2, thickness int) {
+	wg := new(sync.WaitGroup)
+	wg.Add(thickness * (((x2 - x1) * 2) + ((y2 - y1 + 1) * 2)))
+
+	for t := 0; t < thickness; t++ {
+		for i := x1; i < x2; i++ {
+			go func(i, t int) {
+				defer wg.Done()
+				img.Set(i, y1+t, color)
+			}(i, t)
+			go func(i, t int) {
+				defer wg.Done()
+				img.Set(i, y2-t, color)
+			}(i, t)
+		}
+
+		for i := y1; i <= y2; i++ {
+			go func(i, t int) {
+				defer wg.Done()
+				img.Set(x1+t, i, color)
+			}(i, t)
+			go func(i, t int) {
+				defer wg.Done()
+				img.Set(x2-t, i, color)
+			}(i, t)
+		}
+	}
+
+	wg.Wait()
+}
+
+// ลำดับการวาดเดียวกับ drawRectangleLegacy แต่ไม่แยก goroutine เพื่อใช้เทียบผลลัพธ์ภายใต้ -race
+func drawRectangleReference(img draw.Image, color color.Color, x1, y1, x2, y2, thickness int) {
+	for t := 0; t < thickness; t++ {
+		for i := x1; i < x2; i++ {
+			img.Set(i, y1+t, color)
+			img.Set(i, y2-t, color)
+		}
+		for i := y1; i <= y2; i++ {
+			img.Set(x1+t, i, color)
+			img.Set(x2-t, i, color)
+		}
+	}
+}
+
+func randomBoxes(bounds image.Rectangle, n int) []image.Rectangle {
+	r := rand.New(rand.NewSource(1))
+	boxes := make([]image.Rectangle, n)
+	for i := range boxes {
+		w := 20 + r.Intn(bounds.Dx()/4)
+		h := 20 + r.Intn(bounds.Dy()/4)
+		x := r.Intn(bounds.Dx() - w - 1)
+		y := r.Intn(bounds.Dy() - h - 1)
+		boxes[i] = image.Rect(x, y, x+w, y+h)
+	}
+	return boxes
+}
+
+func TestStrokeRectMatchesLegacy(t *testing.T) {
+	bounds := image.Rect(0, 0, 320, 240)
+	for _, thickness := range []int{1, 2, 5} {
+		t.Run(fmt.Sprint("thickness ", thickness), func(t *testing.T) {
+			// --------------- Arrange ---------------
+			legacy := image.NewRGBA(bounds)
+			fast := image.NewRGBA(bounds)
+			c := color.RGBA{255, 0, 0, 255}
+
+			// --------------- Act ---------------
+			for _, b := range randomBoxes(bounds, 10) {
+				drawRectangleReference(legacy, c, b.Min.X, b.Min.Y, b.Max.X, b.Max.Y, thickness)
+				drawRectangle(fast, PlotStyle{Color: c, Thickness: thickness}, b.Min.X, b.Min.Y, b.Max.X, b.Max.Y)
+			}
+
+			// --------------- Assert ---------------
+			assert.Equal(t, legacy.Pix, fast.Pix)
+		})
+	}
+}
+
+func TestFillRect(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Image draw.Image
+		Color color.Color
+	}{
+		{Name: "RGBA opaque", Image: image.NewRGBA(image.Rect(0, 0, 8, 8)), Color: color.RGBA{10, 20, 30, 255}},
+		{Name: "RGBA translucent", Image: image.NewRGBA(image.Rect(0, 0, 8, 8)), Color: color.NRGBA{10, 20, 30, 100}},
+		{Name: "NRGBA fallback", Image: image.NewNRGBA(image.Rect(0, 0, 8, 8)), Color: color.NRGBA{10, 20, 30, 100}},
+		{Name: "Gray fallback", Image: image.NewGray(image.Rect(0, 0, 8, 8)), Color: color.White},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Arrange ---------------
+			expected := image.NewRGBA(tt.Image.Bounds())
+			draw.Draw(tt.Image, tt.Image.Bounds(), image.NewUniform(color.RGBA{200, 100, 50, 255}), image.Point{}, draw.Src)
+			draw.Draw(expected, expected.Bounds(), tt.Image, image.Point{}, draw.Src)
+			draw.Draw(expected, image.Rect(2, 2, 6, 6), image.NewUniform(tt.Color), image.Point{}, draw.Over)
+
+			// --------------- Act ---------------
+			fillRect(tt.Image, image.Rect(2, 2, 6, 6), tt.Color)
+
+			// --------------- Assert ---------------
+			for y := 0; y < 8; y++ {
+				for x := 0; x < 8; x++ {
+					assert.Equal(t, color.RGBAModel.Convert(expected.At(x, y)), color.RGBAModel.Convert(tt.Image.At(x, y)), "pixel %d,%d", x, y)
+				}
+			}
+		})
+	}
+}
+
+func TestDrawLine(t *testing.T) {
+	tests := []struct {
+		Name     string
+		From, To image.Point
+		Expected []image.Point
+	}{
+		{Name: "Horizontal", From: image.Pt(1, 1), To: image.Pt(4, 1), Expected: []image.Point{{1, 1}, {2, 1}, {3, 1}, {4, 1}}},
+		{Name: "Diagonal", From: image.Pt(3, 3), To: image.Pt(0, 0), Expected: []image.Point{{0, 0}, {1, 1}, {2, 2}, {3, 3}}},
+		{Name: "Single point", From: image.Pt(2, 2), To: image.Pt(2, 2), Expected: []image.Point{{2, 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Arrange ---------------
+			img := image.NewRGBA(image.Rect(0, 0, 6, 6))
+
+			// --------------- Act ---------------
+			drawLine(img, tt.From, tt.To, 1, color.White)
+
+			// --------------- Assert ---------------
+			var result []image.Point
+			for y := 0; y < 6; y++ {
+				for x := 0; x < 6; x++ {
+					if img.RGBAAt(x, y).A != 0 {
+						result = append(result, image.Pt(x, y))
+					}
+				}
+			}
+			assert.Equal(t, tt.Expected, result)
+		})
+	}
+}
+
+func TestFillPolygon(t *testing.T) {
+	// --------------- Arrange ---------------
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	triangle := []image.Point{{1, 1}, {8, 1}, {1, 8}}
+
+	// --------------- Act ---------------
+	fillPolygon(img, triangle, color.NRGBA{0, 0, 0, 128})
+
+	// --------------- Assert ---------------
+	assert.Equal(t, uint8(128), img.RGBAAt(1, 1).A)
+	assert.Equal(t, uint8(128), img.RGBAAt(2, 5).A)
+	assert.Equal(t, uint8(128), img.RGBAAt(4, 4).A)
+	assert.Equal(t, uint8(0), img.RGBAAt(7, 7).A)
+	assert.Equal(t, uint8(0), img.RGBAAt(0, 0).A)
+}
+
+func TestDrawRing(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Thickness int
+		Center    uint8
+	}{
+		{Name: "Outline", Thickness: 1, Center: 0},
+		{Name: "Filled", Thickness: 5, Center: 255},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Arrange ---------------
+			img := image.NewRGBA(image.Rect(0, 0, 11, 11))
+
+			// --------------- Act ---------------
+			drawRing(img, image.Pt(5, 5), 4, tt.Thickness, color.White)
+
+			// --------------- Assert ---------------
+			for _, p := range []image.Point{{1, 5}, {9, 5}, {5, 1}, {5, 9}} {
+				assert.Equal(t, uint8(255), img.RGBAAt(p.X, p.Y).A, "edge %v", p)
+			}
+			assert.Equal(t, tt.Center, img.RGBAAt(5, 5).A)
+			assert.Equal(t, uint8(0), img.RGBAAt(0, 0).A)
+			assert.Equal(t, uint8(0), img.RGBAAt(10, 5).A)
+		})
+	}
+}
+
+func BenchmarkDrawRectangle(b *testing.B) {
+	sizes := []struct {
+		Name   string
+		Bounds image.Rectangle
+		Boxes  int
+	}{
+		{Name: "640x480/10boxes", Bounds: image.Rect(0, 0, 640, 480), Boxes: 10},
+		{Name: "3840x2160/50boxes", Bounds: image.Rect(0, 0, 3840, 2160), Boxes: 50},
+	}
+	c := color.RGBA{255, 0, 0, 255}
+	for _, size := range sizes {
+		boxes := randomBoxes(size.Bounds, size.Boxes)
+		img := image.NewRGBA(size.Bounds)
+
+		b.Run("legacy/"+size.Name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, r := range boxes {
+					style := PlotStyle{Color: c}.resolve(r)
+					drawRectangleLegacy(img, c, r.Min.X, r.Min.Y, r.Max.X, r.Max.Y, style.Thickness)
+				}
+			}
+		})
+		b.Run("fast/"+size.Name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, r := range boxes {
+					drawRectangle(img, PlotStyle{Color: c}.resolve(r), r.Min.X, r.Min.Y, r.Max.X, r.Max.Y)
+				}
+			}
+		})
+	}
+}
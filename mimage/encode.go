@@ -0,0 +1,87 @@
+package mimage
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/tiff"
+)
+
+// error เมื่อขอ encode เป็น format ที่ไม่รองรับ
+var ErrUnsupportedFormat = errors.New("unsupported image format")
+
+// สำหรับกำหนด format ของภาพผลลัพธ์ ("jpeg", "png", "bmp", "tiff") โดยไม่ขึ้นกับ format ของภาพต้นฉบับ
+func WithFormat(format string) Option {
+	return func(o *options) {
+		if format == "jpg" {
+			format = "jpeg"
+		}
+		o.format = format
+	}
+}
+
+// สำหรับกำหนดคุณภาพของ jpeg (1-100) ถ้าไม่กำหนดจะใช้ค่า default ของ image/jpeg (75)
+func WithJPEGQuality(quality int) Option {
+	return func(o *options) {
+		o.jpegQuality = quality
+	}
+}
+
+// สำหรับกำหนดระดับการบีบอัดของ png
+func WithPNGCompression(level png.CompressionLevel) Option {
+	return func(o *options) {
+		o.pngCompression = level
+	}
+}
+
+// สำหรับดูว่า format ของภาพที่ได้จาก PlotImage* จะเป็นอะไร เมื่อภาพต้นฉบับเป็น inputFormat และไม่ได้กำหนด WithFormat
+// format ที่ไม่มี encoder ใน Go (เช่น webp) จะได้ผลลัพธ์เป็น png
+func OutputFormat(inputFormat string) string {
+	switch inputFormat {
+	case "jpeg", "png", "bmp", "tiff":
+		return inputFormat
+	}
+	return "png"
+}
+
+// format ที่จะใช้ encode ตาม WithFormat หรือ OutputFormat ของภาพต้นฉบับถ้าไม่ได้กำหนด
+func (o *options) outputFormat(inputFormat string) string {
+	if o.format != "" {
+		return o.format
+	}
+	return OutputFormat(inputFormat)
+}
+
+// encode ภาพตาม option ที่กำหนด แล้วคืนค่า format ที่ใช้จริง
+func encodeImage(w io.Writer, img image.Image, inputFormat string, o *options) (string, error) {
+	format := o.outputFormat(inputFormat)
+
+	var err error
+	switch format {
+	case "jpeg":
+		var jo *jpeg.Options
+		if o.jpegQuality > 0 {
+			jo = &jpeg.Options{Quality: o.jpegQuality}
+		}
+		err = jpeg.Encode(w, img, jo)
+	case "png":
+		enc := &png.Encoder{CompressionLevel: o.pngCompression}
+		err = enc.Encode(w, img)
+	case "bmp":
+		err = bmp.Encode(w, img)
+	case "tiff":
+		err = tiff.Encode(w, img, nil)
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return format, nil
+}
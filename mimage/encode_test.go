@@ -0,0 +1,95 @@
+package mimage_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlotImageWithFormat(t *testing.T) {
+	plotData := []mimage.PlotDataModel{
+		{
+			Rect:  image.Rect(10, 10, 50, 50),
+			Label: "Test Label 1",
+		},
+	}
+	tests := []struct {
+		Name          string
+		Input         []byte
+		Options       []mimage.Option
+		Expected      string
+		ExpectedError error
+	}{
+		{
+			Name:     "Keep input format",
+			Input:    createTestImage("jpeg"),
+			Expected: "jpeg",
+		},
+		{
+			Name:     "PNG to JPEG",
+			Input:    createTestImage("png"),
+			Options:  []mimage.Option{mimage.WithFormat("jpeg")},
+			Expected: "jpeg",
+		},
+		{
+			Name:     "JPEG to PNG with compression level",
+			Input:    createTestImage("jpeg"),
+			Options:  []mimage.Option{mimage.WithFormat("png"), mimage.WithPNGCompression(png.BestCompression)},
+			Expected: "png",
+		},
+		{
+			Name:     "jpg alias",
+			Input:    createTestImage("png"),
+			Options:  []mimage.Option{mimage.WithFormat("jpg"), mimage.WithJPEGQuality(90)},
+			Expected: "jpeg",
+		},
+		{
+			Name:     "PNG to TIFF",
+			Input:    createTestImage("png"),
+			Options:  []mimage.Option{mimage.WithFormat("tiff")},
+			Expected: "tiff",
+		},
+		{
+			Name:          "Unsupported output format",
+			Input:         createTestImage("png"),
+			Options:       []mimage.Option{mimage.WithFormat("webp")},
+			ExpectedError: mimage.ErrUnsupportedFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result, err := mimage.PlotImageFromBytes(tt.Input, plotData, tt.Options...)
+
+			// --------------- Assert ---------------
+			if tt.ExpectedError != nil {
+				assert.ErrorIs(t, err, tt.ExpectedError)
+				assert.Zero(t, result)
+				return
+			}
+			assert.NoError(t, err)
+			_, format, err := image.Decode(bytes.NewReader(result))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.Expected, format)
+		})
+	}
+}
+
+func TestPlotImageWithJPEGQuality(t *testing.T) {
+	plotData := []mimage.PlotDataModel{
+		{Rect: image.Rect(10, 10, 50, 50)},
+	}
+
+	// --------------- Act ---------------
+	low, errLow := mimage.PlotImageFromDir("../testdata/image_test.jpg", plotData, mimage.WithJPEGQuality(10))
+	high, errHigh := mimage.PlotImageFromDir("../testdata/image_test.jpg", plotData, mimage.WithJPEGQuality(100))
+
+	// --------------- Assert ---------------
+	assert.NoError(t, errLow)
+	assert.NoError(t, errHigh)
+	assert.Less(t, len(low), len(high))
+}
@@ -0,0 +1,146 @@
+package mimage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// ขนาด response สูงสุดที่ HTTPFetcher ยอมอ่าน เมื่อไม่ได้กำหนด MaxBytes
+	DefaultMaxBytes int64 = 32 << 20
+	// timeout ของ http.Client ที่ HTTPFetcher ใช้ เมื่อไม่ได้กำหนด Client
+	DefaultFetchTimeout = 30 * time.Second
+)
+
+var (
+	// error เมื่อ server ตอบกลับด้วย status ที่ไม่ใช่ 2xx ดูรายละเอียดได้จาก *HTTPStatusError
+	ErrHTTPStatus = errors.New("unexpected http status")
+	// error เมื่อ response มีขนาดเกิน MaxBytes
+	ErrTooLarge = errors.New("response body too large")
+	// error เมื่อ Content-Type ของ response ไม่ใช่ภาพ
+	ErrContentType = errors.New("unexpected content type")
+)
+
+// error ที่มี status code ของ response สามารถเช็คด้วย errors.Is(err, ErrHTTPStatus)
+// หรือดึง status code ด้วย errors.As
+type HTTPStatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("%s: %d %s", ErrHTTPStatus, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *HTTPStatusError) Unwrap() error {
+	return ErrHTTPStatus
+}
+
+// สำหรับดึงข้อมูลภาพจาก url ให้ URLSource
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+// Fetcher ที่ URLSource ใช้เมื่อไม่ได้กำหนด Fetcher
+var DefaultFetcher Fetcher = &HTTPFetcher{}
+
+var defaultHTTPClient = &http.Client{Timeout: DefaultFetchTimeout}
+
+// สำหรับดึงภาพผ่าน http โดยเช็ค status code, Content-Type และขนาดของ response
+type HTTPFetcher struct {
+	Client   *http.Client // default: client ที่มี timeout เท่ากับ DefaultFetchTimeout
+	MaxBytes int64        // default: DefaultMaxBytes
+}
+
+type fetchResult struct {
+	data         []byte
+	statusCode   int
+	etag         string
+	lastModified string
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	res, err := f.fetch(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return res.data, nil
+}
+
+// ส่ง request ไปที่ url พร้อม header เพิ่มเติม (ถ้ามี) response 304 จะไม่ถือว่าเป็น error
+func (f *HTTPFetcher) fetch(ctx context.Context, url string, header http.Header) (*fetchResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	client := f.Client
+	if client == nil {
+		client = defaultHTTPClient
+	}
+	maxBytes := f.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+
+	// Read image from url
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	result := &fetchResult{
+		statusCode:   res.StatusCode,
+		etag:         res.Header.Get("ETag"),
+		lastModified: res.Header.Get("Last-Modified"),
+	}
+	if res.StatusCode == http.StatusNotModified {
+		return result, nil
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, &HTTPStatusError{URL: url, StatusCode: res.StatusCode}
+	}
+	if err := checkContentType(res.Header.Get("Content-Type")); err != nil {
+		return nil, err
+	}
+	if res.ContentLength > maxBytes {
+		return nil, ErrTooLarge
+	}
+
+	// Convert file to byte
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, ErrTooLarge
+	}
+	result.data = data
+
+	return result, nil
+}
+
+// ยอมรับ image/* และ octet-stream รวมถึง response ที่ไม่ได้ระบุ Content-Type
+func checkContentType(contentType string) error {
+	if contentType == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrContentType, contentType)
+	}
+	if strings.HasPrefix(mediaType, "image/") || mediaType == "application/octet-stream" || mediaType == "binary/octet-stream" {
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrContentType, mediaType)
+}
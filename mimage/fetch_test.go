@@ -0,0 +1,128 @@
+package mimage_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPFetcher(t *testing.T) {
+	pngData := createTestImage("png")
+	mux := http.NewServeMux()
+	mux.HandleFunc("/image.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(pngData)
+	})
+	mux.HandleFunc("/octet", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(pngData)
+	})
+	mux.HandleFunc("/html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte("<html></html>"))
+	})
+	mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusBadGateway)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		Name          string
+		Fetcher       *mimage.HTTPFetcher
+		Path          string
+		Timeout       time.Duration
+		ExpectedError error
+	}{
+		{
+			Name:    "Image content type",
+			Fetcher: &mimage.HTTPFetcher{},
+			Path:    "/image.png",
+		},
+		{
+			Name:    "Octet stream content type",
+			Fetcher: &mimage.HTTPFetcher{Client: server.Client()},
+			Path:    "/octet",
+		},
+		{
+			Name:          "Not found",
+			Fetcher:       &mimage.HTTPFetcher{},
+			Path:          "/missing.png",
+			ExpectedError: mimage.ErrHTTPStatus,
+		},
+		{
+			Name:          "Server error",
+			Fetcher:       &mimage.HTTPFetcher{},
+			Path:          "/error",
+			ExpectedError: mimage.ErrHTTPStatus,
+		},
+		{
+			Name:          "Unexpected content type",
+			Fetcher:       &mimage.HTTPFetcher{},
+			Path:          "/html",
+			ExpectedError: mimage.ErrContentType,
+		},
+		{
+			Name:          "Body too large",
+			Fetcher:       &mimage.HTTPFetcher{MaxBytes: 10},
+			Path:          "/image.png",
+			ExpectedError: mimage.ErrTooLarge,
+		},
+		{
+			Name:          "Context deadline",
+			Fetcher:       &mimage.HTTPFetcher{},
+			Path:          "/slow",
+			Timeout:       50 * time.Millisecond,
+			ExpectedError: context.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Arrange ---------------
+			ctx := context.Background()
+			if tt.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.Timeout)
+				defer cancel()
+			}
+
+			// --------------- Act ---------------
+			result, err := tt.Fetcher.Fetch(ctx, server.URL+tt.Path)
+
+			// --------------- Assert ---------------
+			if tt.ExpectedError != nil {
+				assert.ErrorIs(t, err, tt.ExpectedError)
+				assert.Zero(t, result)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, pngData, result)
+		})
+	}
+}
+
+func TestHTTPStatusError(t *testing.T) {
+	// --------------- Arrange ---------------
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	// --------------- Act ---------------
+	_, _, err := mimage.PlotImage(context.Background(), mimage.URLSource{URL: server.URL, Fetcher: &mimage.HTTPFetcher{}}, nil)
+
+	// --------------- Assert ---------------
+	var statusErr *mimage.HTTPStatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+}
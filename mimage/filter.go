@@ -0,0 +1,38 @@
+package mimage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inetmanageai/utils/mslices"
+)
+
+// สำหรับสร้างข้อความ label จากข้อมูลของกรอบ
+type LabelFormatter func(p PlotDataModel) string
+
+// ใช้ Label ถ้ามี ไม่อย่างนั้นจะประกอบจาก Class และ Score เช่น "face 0.97"
+func DefaultLabelFormatter(p PlotDataModel) string {
+	if p.Label != "" {
+		return p.Label
+	}
+
+	parts := make([]string, 0, 2)
+	if p.Class != "" {
+		parts = append(parts, p.Class)
+	}
+	if p.Score > 0 {
+		parts = append(parts, fmt.Sprintf("%.2f", p.Score))
+	}
+	return strings.Join(parts, " ")
+}
+
+// กรอบที่ผ่านเงื่อนไข WithMinScore และ WithClasses
+func (o *options) keep(p PlotDataModel) bool {
+	if p.Score < o.minScore {
+		return false
+	}
+	if len(o.classes) > 0 && !mslices.Contains(o.classes, p.Class) {
+		return false
+	}
+	return true
+}
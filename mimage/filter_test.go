@@ -0,0 +1,87 @@
+package mimage_test
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultLabelFormatter(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Input    mimage.PlotDataModel
+		Expected string
+	}{
+		{Name: "Label wins", Input: mimage.PlotDataModel{Label: "Somchai", Class: "face", Score: 0.97}, Expected: "Somchai"},
+		{Name: "Class and score", Input: mimage.PlotDataModel{Class: "face", Score: 0.9712}, Expected: "face 0.97"},
+		{Name: "Class only", Input: mimage.PlotDataModel{Class: "face"}, Expected: "face"},
+		{Name: "Score only", Input: mimage.PlotDataModel{Score: 0.5}, Expected: "0.50"},
+		{Name: "Nothing", Input: mimage.PlotDataModel{}, Expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result := mimage.DefaultLabelFormatter(tt.Input)
+
+			// --------------- Assert ---------------
+			assert.Equal(t, tt.Expected, result)
+		})
+	}
+}
+
+func TestPlotImageWithFilter(t *testing.T) {
+	plotData := []mimage.PlotDataModel{
+		{Rect: image.Rect(10, 10, 40, 40), Class: "face", Score: 0.9},
+		{Rect: image.Rect(60, 10, 90, 40), Class: "face", Score: 0.3},
+		{Rect: image.Rect(110, 10, 140, 40), Class: "person", Score: 0.8},
+	}
+	tests := []struct {
+		Name     string
+		Options  []mimage.Option
+		Expected []bool
+	}{
+		{Name: "No filter", Expected: []bool{true, true, true}},
+		{Name: "Minimum score", Options: []mimage.Option{mimage.WithMinScore(0.5)}, Expected: []bool{true, false, true}},
+		{Name: "Allowed classes", Options: []mimage.Option{mimage.WithClasses("face")}, Expected: []bool{true, true, false}},
+		{Name: "Score and class", Options: []mimage.Option{mimage.WithMinScore(0.5), mimage.WithClasses("face")}, Expected: []bool{true, false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result, err := mimage.PlotImageFromBytes(createTestImage("png"), plotData, tt.Options...)
+
+			// --------------- Assert ---------------
+			assert.NoError(t, err)
+			img, _, err := image.Decode(bytes.NewReader(result))
+			assert.NoError(t, err)
+			for i, p := range plotData {
+				// ขอบล่างของกรอบไม่ถูกป้าย label บัง
+				drawn := color.RGBAModel.Convert(img.At(p.Rect.Min.X+5, p.Rect.Max.Y)) != color.RGBA{255, 255, 255, 255}
+				assert.Equal(t, tt.Expected[i], drawn, fmt.Sprint("plot ", i))
+			}
+		})
+	}
+}
+
+func TestPlotImageWithLabelFormatter(t *testing.T) {
+	// --------------- Arrange ---------------
+	plotData := []mimage.PlotDataModel{{Rect: image.Rect(10, 40, 100, 100), Class: "face", Score: 0.97}}
+	var labels []string
+	formatter := func(p mimage.PlotDataModel) string {
+		label := fmt.Sprintf("%s (%.0f%%)", p.Class, p.Score*100)
+		labels = append(labels, label)
+		return label
+	}
+
+	// --------------- Act ---------------
+	_, err := mimage.PlotImageFromBytes(createTestImage("png"), plotData, mimage.WithLabelFormatter(formatter))
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"face (97%)"}, labels)
+}
@@ -0,0 +1,214 @@
+package mimage
+
+import (
+	"errors"
+	"image"
+	"os"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// error เมื่อ FontRegistry ไม่มี font อยู่เลย
+var ErrNoFont = errors.New("no font registered")
+
+// registry ที่ใช้วาด label เมื่อไม่ได้กำหนด WithFonts มี Go Regular ไว้เป็น font แรก
+// สามารถ Register font ภาษาไทย (เช่น Sarabun, Noto Sans Thai) เพิ่มเพื่อใช้เป็น fallback ได้
+var DefaultFonts = mustFontRegistry(goregular.TTF)
+
+// สำหรับเก็บ font ที่ parse แล้ว และ cache font.Face ตามขนาด
+// ตัวอักษรแต่ละตัวจะใช้ font แรกตามลำดับการ Register ที่มี glyph นั้น
+// ปลอดภัยเมื่อเรียกใช้จากหลาย goroutine พร้อมกัน
+type FontRegistry struct {
+	mu    sync.RWMutex
+	fonts []*sfnt.Font
+	faces map[float64]*sync.Pool
+	gen   int // เพิ่มทุกครั้งที่ Register เพื่อไม่ให้ face เก่ากลับเข้า cache
+}
+
+// สำหรับสร้าง FontRegistry จาก font data (TTF/OTF) ตามลำดับ fallback
+func NewFontRegistry(fonts ...[]byte) (*FontRegistry, error) {
+	r := &FontRegistry{}
+	for _, data := range fonts {
+		if err := r.Register(data); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func mustFontRegistry(fonts ...[]byte) *FontRegistry {
+	r, err := NewFontRegistry(fonts...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// สำหรับเพิ่ม font (TTF/OTF หรือ collection TTC/OTC) ต่อท้ายลำดับ fallback
+func (r *FontRegistry) Register(data []byte) error {
+	var fonts []*sfnt.Font
+	if f, err := opentype.Parse(data); err == nil {
+		fonts = append(fonts, f)
+	} else {
+		c, cerr := opentype.ParseCollection(data)
+		if cerr != nil {
+			return err
+		}
+		for i := 0; i < c.NumFonts(); i++ {
+			f, err := c.Font(i)
+			if err != nil {
+				return err
+			}
+			fonts = append(fonts, f)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fonts = append(r.fonts, fonts...)
+	r.faces = nil // ล้าง cache เพราะลำดับ fallback เปลี่ยน
+	r.gen++
+
+	return nil
+}
+
+// สำหรับเพิ่ม font จาก path ของไฟล์
+func (r *FontRegistry) RegisterFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return r.Register(data)
+}
+
+// สำหรับสร้าง font.Face ขนาด size (pixel) ที่ fallback ข้าม font ตามลำดับการ Register
+// face ที่ได้ไม่ปลอดภัยเมื่อใช้จากหลาย goroutine พร้อมกัน ให้สร้างแยกต่อ goroutine
+func (r *FontRegistry) Face(size float64) (font.Face, error) {
+	r.mu.RLock()
+	fonts := r.fonts
+	r.mu.RUnlock()
+
+	return newFallbackFace(fonts, size)
+}
+
+// ยืม face ขนาด size จาก cache ต้องคืนด้วย release เมื่อใช้เสร็จ
+func (r *FontRegistry) acquire(size float64) (*fallbackFace, error) {
+	r.mu.Lock()
+	pool := r.pool(size)
+	fonts, gen := r.fonts, r.gen
+	r.mu.Unlock()
+
+	if face, ok := pool.Get().(*fallbackFace); ok {
+		return face, nil
+	}
+	face, err := newFallbackFace(fonts, size)
+	if err != nil {
+		return nil, err
+	}
+	face.gen = gen
+	return face, nil
+}
+
+func (r *FontRegistry) release(face *fallbackFace) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if face.gen != r.gen {
+		face.Close()
+		return
+	}
+	r.pool(face.size).Put(face)
+}
+
+// ต้องถือ r.mu อยู่
+func (r *FontRegistry) pool(size float64) *sync.Pool {
+	if r.faces == nil {
+		r.faces = make(map[float64]*sync.Pool)
+	}
+	p, ok := r.faces[size]
+	if !ok {
+		p = new(sync.Pool)
+		r.faces[size] = p
+	}
+	return p
+}
+
+// font.Face ที่เลือก face แรกที่มี glyph ของตัวอักษรนั้น ถ้าไม่มีเลยจะใช้ face แรก
+type fallbackFace struct {
+	fonts []*sfnt.Font
+	faces []font.Face
+	buf   sfnt.Buffer
+	size  float64
+	gen   int
+}
+
+func newFallbackFace(fonts []*sfnt.Font, size float64) (*fallbackFace, error) {
+	if len(fonts) == 0 {
+		return nil, ErrNoFont
+	}
+
+	f := &fallbackFace{fonts: fonts, faces: make([]font.Face, len(fonts)), size: size}
+	for i, fnt := range fonts {
+		face, err := opentype.NewFace(fnt, &opentype.FaceOptions{Size: size, DPI: 72})
+		if err != nil {
+			return nil, err
+		}
+		f.faces[i] = face
+	}
+	return f, nil
+}
+
+func (f *fallbackFace) index(r rune) int {
+	for i, fnt := range f.fonts {
+		if idx, err := fnt.GlyphIndex(&f.buf, r); err == nil && idx != 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+func (f *fallbackFace) Close() error {
+	var errs []error
+	for _, face := range f.faces {
+		errs = append(errs, face.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func (f *fallbackFace) Glyph(dot fixed.Point26_6, r rune) (dr image.Rectangle, mask image.Image, maskp image.Point, advance fixed.Int26_6, ok bool) {
+	return f.faces[f.index(r)].Glyph(dot, r)
+}
+
+func (f *fallbackFace) GlyphBounds(r rune) (bounds fixed.Rectangle26_6, advance fixed.Int26_6, ok bool) {
+	return f.faces[f.index(r)].GlyphBounds(r)
+}
+
+func (f *fallbackFace) GlyphAdvance(r rune) (advance fixed.Int26_6, ok bool) {
+	return f.faces[f.index(r)].GlyphAdvance(r)
+}
+
+// kerning ใช้ได้เฉพาะตัวอักษรที่มาจาก font เดียวกัน
+func (f *fallbackFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	i := f.index(r0)
+	if i != f.index(r1) {
+		return 0
+	}
+	return f.faces[i].Kern(r0, r1)
+}
+
+// ใช้ค่าที่มากที่สุดจากทุก font เพื่อให้ตัวอักษรที่สูงกว่า (เช่น สระและวรรณยุกต์ไทย) ไม่ล้นพื้นที่
+func (f *fallbackFace) Metrics() font.Metrics {
+	m := f.faces[0].Metrics()
+	for _, face := range f.faces[1:] {
+		fm := face.Metrics()
+		m.Height = max(m.Height, fm.Height)
+		m.Ascent = max(m.Ascent, fm.Ascent)
+		m.Descent = max(m.Descent, fm.Descent)
+	}
+	return m
+}
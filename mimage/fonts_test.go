@@ -0,0 +1,129 @@
+package mimage_test
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+func TestFontRegistryRegister(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Register      func(r *mimage.FontRegistry) error
+		ExpectedError bool
+	}{
+		{
+			Name:     "Register TTF bytes",
+			Register: func(r *mimage.FontRegistry) error { return r.Register(goregular.TTF) },
+		},
+		{
+			Name:     "Register OTF file",
+			Register: func(r *mimage.FontRegistry) error { return r.RegisterFile("../testdata/CFFTest.otf") },
+		},
+		{
+			Name:          "Register invalid bytes",
+			Register:      func(r *mimage.FontRegistry) error { return r.Register([]byte("not a font")) },
+			ExpectedError: true,
+		},
+		{
+			Name:          "Register missing file",
+			Register:      func(r *mimage.FontRegistry) error { return r.RegisterFile("../testdata/missing.ttf") },
+			ExpectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Arrange ---------------
+			r, _ := mimage.NewFontRegistry()
+
+			// --------------- Act ---------------
+			err := tt.Register(r)
+
+			// --------------- Assert ---------------
+			if tt.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			face, err := r.Face(12)
+			assert.NoError(t, err)
+			assert.NoError(t, face.Close())
+		})
+	}
+}
+
+func TestFontRegistryEmpty(t *testing.T) {
+	// --------------- Arrange ---------------
+	r, _ := mimage.NewFontRegistry()
+
+	// --------------- Act ---------------
+	_, err := r.Face(12)
+
+	// --------------- Assert ---------------
+	assert.ErrorIs(t, err, mimage.ErrNoFont)
+}
+
+func TestFontRegistryFallback(t *testing.T) {
+	// --------------- Arrange ---------------
+	otf, err := os.ReadFile("../testdata/CFFTest.otf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// CFFTest.otf มีแค่ตัวเลขบางตัว ตัวอักษรอื่นต้อง fallback ไปที่ Go Mono
+	r, err := mimage.NewFontRegistry(otf, gomono.TTF)
+	assert.NoError(t, err)
+	cff, _ := opentype.Parse(otf)
+	cffFace, _ := opentype.NewFace(cff, &opentype.FaceOptions{Size: 20, DPI: 72})
+	mono, _ := opentype.Parse(gomono.TTF)
+	monoFace, _ := opentype.NewFace(mono, &opentype.FaceOptions{Size: 20, DPI: 72})
+
+	// --------------- Act ---------------
+	face, err := r.Face(20)
+	assert.NoError(t, err)
+	digit, okDigit := face.GlyphAdvance('0')
+	letter, okLetter := face.GlyphAdvance('A')
+
+	// --------------- Assert ---------------
+	expectedDigit, _ := cffFace.GlyphAdvance('0')
+	expectedLetter, _ := monoFace.GlyphAdvance('A')
+	assert.True(t, okDigit)
+	assert.True(t, okLetter)
+	assert.Equal(t, expectedDigit, digit)
+	assert.Equal(t, expectedLetter, letter)
+}
+
+func TestPlotImageWithFonts(t *testing.T) {
+	// --------------- Arrange ---------------
+	r, err := mimage.NewFontRegistry(gomono.TTF)
+	assert.NoError(t, err)
+	wg := new(sync.WaitGroup)
+
+	// --------------- Act ---------------
+	// ใช้ registry เดียวกันจากหลาย goroutine พร้อมกัน
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{
+				{
+					Rect:  image.Rect(10, 40, 150, 150),
+					Label: "Label 0123",
+					Style: mimage.PlotStyle{Color: color.Black, FontSize: 24},
+				},
+			}, mimage.WithFonts(r))
+
+			// --------------- Assert ---------------
+			assert.NoError(t, err)
+			assert.NotZero(t, result)
+		}()
+	}
+	wg.Wait()
+}
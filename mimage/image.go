@@ -1,222 +1,103 @@
 package mimage
 
 import (
-	"bytes"
+	"context"
 	"image"
 	"image/color"
 	"image/draw"
-	"image/jpeg"
-	"image/png"
-	"io"
 	"math"
-	"net/http"
-	"os"
-	"sync"
-
-	"github.com/golang/freetype/truetype"
-	"golang.org/x/image/font"
-	"golang.org/x/image/font/gofont/goregular"
-	"golang.org/x/image/math/fixed"
+
+	_ "golang.org/x/image/webp"
 )
 
 type PlotDataModel struct {
 	Rect  image.Rectangle
+	Box   *Box  // ถ้ากำหนด จะใช้แทน Rect โดยคำนวณจากขนาดของภาพตอนวาด
+	Shape Shape // ถ้ากำหนด จะวาดรูปทรงนี้แทนกรอบสี่เหลี่ยม และใช้ Shape.Bounds() แทน Rect
+	Mask  *Mask // ถ้ากำหนด จะระบาย mask ก่อนวาดเส้นกรอบ ถ้าไม่มี Rect, Box และ Shape จะวาดเฉพาะ mask
 	Label string
-}
-
-func drawRectangle(img draw.Image, color color.Color, x1, y1, x2, y2, thickness int, label string) {
-	wg := new(sync.WaitGroup)
-	wg.Add(thickness * (((x2 - x1) * 2) + ((y2 - y1 + 1) * 2)))
-
-	for t := 0; t < thickness; t++ {
-		for i := x1; i < x2; i++ {
-			go func(i, t int) {
-				defer wg.Done()
-				img.Set(i, y1+t, color)
-			}(i, t)
-			go func(i, t int) {
-				defer wg.Done()
-				img.Set(i, y2-t, color)
-			}(i, t)
-		}
-
-		for i := y1; i <= y2; i++ {
-			go func(i, t int) {
-				defer wg.Done()
-				img.Set(x1+t, i, color)
-			}(i, t)
-			go func(i, t int) {
-				defer wg.Done()
-				img.Set(x2-t, i, color)
-			}(i, t)
-		}
-	}
-
-	// draw label
-	if label != "" {
-		f, _ := truetype.Parse(goregular.TTF)
-		d := &font.Drawer{
-			Dst: img,
-			Src: image.NewUniform(color),
-			Face: truetype.NewFace(f, &truetype.Options{
-				Size: float64(thickness) * 8,
-			}),
-			Dot: fixed.Point26_6{X: fixed.Int26_6(x1 * 64), Y: fixed.Int26_6((y1 - thickness) * 64)},
-		}
-		d.DrawString(label)
-	}
+	Style PlotStyle
 
-	wg.Wait()
+	Class string  // ชื่อ class ของ detection เช่น "face"
+	Score float64 // ค่าความมั่นใจ 0-1 (0 คือไม่มีค่า)
 }
 
-func addRectangleToFace(img draw.Image, rect image.Rectangle, label string) draw.Image {
-	// กำหนดสีที่ใช้วาด
-	myColor := color.RGBA{255, 0, 0, 255}
+// สำหรับกำหนดรูปแบบการวาดของแต่ละกรอบ field ไหนที่เป็น zero value จะใช้ค่า default เดิม
+type PlotStyle struct {
+	Color      color.Color // สีเส้นกรอบ (default: แดง)
+	Thickness  int         // ความหนาเส้นเป็น pixel (default: 1% ของด้านที่สั้นกว่า อย่างน้อย 1)
+	FontSize   float64     // ขนาดตัวอักษรของ label (default: thickness * 8)
+	LabelColor color.Color // สีตัวอักษรของ label (default: ขาวหรือดำตามความสว่างของพื้นป้าย)
+	FillColor  color.Color // สีระบายพื้นในกรอบ ควรกำหนด alpha ให้โปร่งแสง (default: ไม่ระบาย)
 
-	min := rect.Min
-	max := rect.Max
-
-	// กำหนดความหนาเส้น
-	thickness := math.Min(float64(max.X-min.X), float64(max.Y-min.Y)) * 0.01
-	if thickness < 1 {
-		thickness = 1
-	}
-
-	drawRectangle(img, myColor, min.X, min.Y, max.X, max.Y, int(thickness), label)
-
-	return img
+	LabelBackground color.Color // สีพื้นป้าย label (default: สีเดียวกับเส้นกรอบ)
 }
 
-func getImageFromFilePath(filePath string) (draw.Image, string, error) {
+var defaultColor = color.RGBA{255, 0, 0, 255}
 
-	// read file
-	f, err := os.Open(filePath)
-	if err != nil {
-		return nil, "", err
+// เติมค่า default ให้กับ field ที่ไม่ได้กำหนด โดยคำนวณจากขนาดของกรอบ
+func (s PlotStyle) resolve(rect image.Rectangle) PlotStyle {
+	if s.Color == nil {
+		s.Color = defaultColor
 	}
-	defer f.Close()
-
-	// convert as image.Image
-	orig, typeImage, err := image.Decode(f)
-	if err != nil {
-		return nil, "", err
+	if s.Thickness <= 0 {
+		// กำหนดความหนาเส้น
+		thickness := math.Min(float64(rect.Dx()), float64(rect.Dy())) * 0.01
+		if thickness < 1 {
+			thickness = 1
+		}
+		s.Thickness = int(thickness)
 	}
-
-	// สร้าง instance image สำหรับแก้ไขไฟล์ภาพ
-	b := orig.Bounds()                                     // ดึงขนาด orig img
-	img := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy())) // กำหนด instance ขนาดเท่ากับ orig img
-	draw.Draw(img, img.Bounds(), orig, b.Min, draw.Src)    // copy orig image ใส่ใน instance ขนาดตามที่กำหนด
-
-	return img, typeImage, err
-}
-
-func getImageFromUrl(url string) (draw.Image, string, error) {
-	// Read image from url
-	res, err := http.Get(url)
-	if err != nil {
-		return nil, "", err
+	if s.FontSize <= 0 {
+		s.FontSize = float64(s.Thickness) * 8
 	}
-	defer res.Body.Close()
-
-	// Convert file to byte
-	data, _ := io.ReadAll(res.Body)
-
-	// convert as image.Image
-	orig, typeImage, err := image.Decode(bytes.NewReader(data))
-	if err != nil {
-		return nil, "", err
+	if s.LabelBackground == nil {
+		s.LabelBackground = s.Color
 	}
-
-	// สร้าง instance image สำหรับแก้ไขไฟล์ภาพ
-	b := orig.Bounds()                                     // ดึงขนาด orig img
-	img := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy())) // กำหนด instance ขนาดเท่ากับ orig img
-	draw.Draw(img, img.Bounds(), orig, b.Min, draw.Src)    // copy orig image ใส่ใน instance ขนาดตามที่กำหนด
-
-	return img, typeImage, err
+	return s
 }
 
-func PlotImageFromUrl(url string, plotData []PlotDataModel) (result []byte, err error) {
-	// read file and convert it
-	src, t, err := getImageFromUrl(url)
-	if err != nil {
-		return nil, err
-	}
-
-	var dst draw.Image
-	for idx, p := range plotData {
-		if idx == 0 {
-			dst = addRectangleToFace(src, p.Rect, p.Label)
-			continue
-		}
-		dst = addRectangleToFace(dst, p.Rect, p.Label)
+func drawRectangle(img draw.Image, style PlotStyle, x1, y1, x2, y2 int) {
+	if style.FillColor != nil {
+		fillRect(img, image.Rect(x1, y1, x2, y2), style.FillColor)
 	}
 
-	buf := new(bytes.Buffer)
-	switch t {
-	case "jpeg":
-		jpeg.Encode(buf, dst, nil)
-	case "png":
-		png.Encode(buf, dst)
-	}
-
-	return buf.Bytes(), nil
+	// กรอบครอบคลุมถึง pixel ที่ x2 และ y2 ด้วย
+	strokeRect(img, image.Rect(x1, y1, x2+1, y2+1), style.Thickness, style.Color)
 }
 
-func PlotImageFromBytes(data []byte, plotData []PlotDataModel) (result []byte, err error) {
-	// convert as image.Image
-	orig, t, err := image.Decode(bytes.NewReader(data))
-	if err != nil {
-		return nil, err
-	}
+// วาดกรอบ (หรือ Shape) ของ p แล้วคืนค่า label ที่ยังไม่ได้วัดขนาด เพื่อนำไปวาดหลังจากวาดกรอบครบทุกอันแล้ว
+func addRectangleToFace(img draw.Image, p PlotDataModel, label string) labelTag {
+	// กำหนดสีและความหนาที่ใช้วาด
+	style := p.Style.resolve(p.Rect)
 
-	// สร้าง instance image สำหรับแก้ไขไฟล์ภาพ
-	b := orig.Bounds()                                     // ดึงขนาด orig img
-	img := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy())) // กำหนด instance ขนาดเท่ากับ orig img
-	draw.Draw(img, img.Bounds(), orig, b.Min, draw.Src)    // copy orig image ใส่ใน instance ขนาดตามที่กำหนด
+	min := p.Rect.Min
+	max := p.Rect.Max
 
-	var dst draw.Image
-	for idx, p := range plotData {
-		if idx == 0 {
-			dst = addRectangleToFace(img, p.Rect, p.Label)
-			continue
-		}
-		dst = addRectangleToFace(dst, p.Rect, p.Label)
+	if p.Shape != nil {
+		p.Shape.Draw(img, style)
+	} else {
+		drawRectangle(img, style, min.X, min.Y, max.X, max.Y)
 	}
 
-	buf := new(bytes.Buffer)
-	switch t {
-	case "jpeg":
-		jpeg.Encode(buf, dst, nil)
-	case "png":
-		png.Encode(buf, dst)
+	return labelTag{
+		anchor: image.Rect(min.X, min.Y, max.X+1, max.Y+1),
+		text:   label,
+		style:  style,
 	}
-
-	return buf.Bytes(), nil
 }
 
-func PlotImageFromDir(filePath string, plotData []PlotDataModel) (result []byte, err error) {
-	// convert as image.Image
-	src, t, err := getImageFromFilePath(filePath)
-	if err != nil {
-		return nil, err
-	}
-
-	var dst draw.Image
-	for idx, p := range plotData {
-		if idx == 0 {
-			dst = addRectangleToFace(src, p.Rect, p.Label)
-			continue
-		}
-		dst = addRectangleToFace(dst, p.Rect, p.Label)
-	}
+func PlotImageFromUrl(url string, plotData []PlotDataModel, opts ...Option) (result []byte, err error) {
+	result, _, err = PlotImage(context.Background(), URLSource{URL: url}, plotData, opts...)
+	return result, err
+}
 
-	buf := new(bytes.Buffer)
-	switch t {
-	case "jpeg":
-		jpeg.Encode(buf, dst, nil)
-	case "png":
-		png.Encode(buf, dst)
-	}
+func PlotImageFromBytes(data []byte, plotData []PlotDataModel, opts ...Option) (result []byte, err error) {
+	result, _, err = PlotImage(context.Background(), BytesSource{Data: data}, plotData, opts...)
+	return result, err
+}
 
-	return buf.Bytes(), nil
+func PlotImageFromDir(filePath string, plotData []PlotDataModel, opts ...Option) (result []byte, err error) {
+	result, _, err = PlotImage(context.Background(), FileSource{Path: filePath}, plotData, opts...)
+	return result, err
 }
@@ -2,18 +2,21 @@ package mimage_test
 
 import (
 	"bytes"
+	"fmt"
 	"image"
 	"image/color"
 	"image/draw"
 	"image/jpeg"
 	"image/png"
-	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
 	"github.com/inetmanageai/utils/mimage"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/tiff"
 )
 
 func createTestImage(types string) []byte {
@@ -26,11 +29,18 @@ func createTestImage(types string) []byte {
 		jpeg.Encode(buf, img, nil)
 	case "png":
 		png.Encode(buf, img)
+	case "bmp":
+		bmp.Encode(buf, img)
+	case "tiff":
+		tiff.Encode(buf, img, nil)
 	}
 	return buf.Bytes()
 }
 
 func TestPlotImageFromUrl(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("../testdata")))
+	defer server.Close()
+
 	type Input struct {
 		URL      string
 		PlotData []mimage.PlotDataModel
@@ -38,13 +48,14 @@ func TestPlotImageFromUrl(t *testing.T) {
 	tests := []struct {
 		Name          string
 		Input         Input
+		File          string
 		ExpectedError bool
 	}{
 		// TODO: Add test cases.
 		{
 			Name: "Valid PNG URL and PlotData",
 			Input: Input{
-				URL: "https://freshfeeds.in/img_urls/img16.png",
+				URL: server.URL + "/image_test.png",
 				PlotData: []mimage.PlotDataModel{
 					{
 						Rect:  image.Rect(10, 10, 50, 50),
@@ -56,12 +67,13 @@ func TestPlotImageFromUrl(t *testing.T) {
 					},
 				},
 			},
+			File:          "../testdata/image_test.png",
 			ExpectedError: false,
 		},
 		{
 			Name: "Valid JPEG URL and PlotData",
 			Input: Input{
-				URL: "https://picsum.photos/200.jpg",
+				URL: server.URL + "/image_test.jpg",
 				PlotData: []mimage.PlotDataModel{
 					{
 						Rect:  image.Rect(10, 10, 50, 50),
@@ -73,12 +85,27 @@ func TestPlotImageFromUrl(t *testing.T) {
 					},
 				},
 			},
+			File:          "../testdata/image_test.jpg",
+			ExpectedError: false,
+		},
+		{
+			Name: "Valid WEBP URL and PlotData",
+			Input: Input{
+				URL: server.URL + "/image_test.webp",
+				PlotData: []mimage.PlotDataModel{
+					{
+						Rect:  image.Rect(10, 10, 50, 50),
+						Label: "Test Label 1",
+					},
+				},
+			},
+			File:          "../testdata/image_test.webp",
 			ExpectedError: false,
 		},
 		{
 			Name: "Invalid URL",
 			Input: Input{
-				URL: "https://picsums.photos/200",
+				URL: "http://invalid host/200",
 				PlotData: []mimage.PlotDataModel{
 					{
 						Rect:  image.Rect(10, 10, 50, 50),
@@ -89,9 +116,9 @@ func TestPlotImageFromUrl(t *testing.T) {
 			ExpectedError: true,
 		},
 		{
-			Name: "Invalid URL type",
+			Name: "Not found URL",
 			Input: Input{
-				URL: "https://picsum.photos/200.webp",
+				URL: server.URL + "/missing.png",
 				PlotData: []mimage.PlotDataModel{
 					{
 						Rect:  image.Rect(10, 10, 50, 50),
@@ -115,17 +142,16 @@ func TestPlotImageFromUrl(t *testing.T) {
 				assert.NoError(t, err)
 				assert.NotZero(t, result)
 				_, typeImgResult, _ := image.Decode(bytes.NewBuffer(result))
-				res, err := http.Get(tt.Input.URL)
+				f, err := os.Open(tt.File)
 				if err != nil {
 					t.Fatal(err)
 				}
-				defer res.Body.Close()
-				f, _ := io.ReadAll(res.Body)
-				_, typeImgSrc, _ := image.Decode(bytes.NewBuffer(f))
+				defer f.Close()
+				_, typeImgSrc, err := image.Decode(f)
 				if err != nil {
 					t.Fatal(err)
 				}
-				assert.Equal(t, typeImgSrc, typeImgResult)
+				assert.Equal(t, mimage.OutputFormat(typeImgSrc), typeImgResult)
 			}
 		})
 	}
@@ -176,6 +202,32 @@ func TestPlotImageFromByte(t *testing.T) {
 			},
 			ExpectedError: false,
 		},
+		{
+			Name: "Valid BMP image data with plot data",
+			Input: Input{
+				Byte: createTestImage("bmp"),
+				PlotData: []mimage.PlotDataModel{
+					{
+						Rect:  image.Rect(10, 10, 50, 50),
+						Label: "Test Label 1",
+					},
+				},
+			},
+			ExpectedError: false,
+		},
+		{
+			Name: "Valid TIFF image data with plot data",
+			Input: Input{
+				Byte: createTestImage("tiff"),
+				PlotData: []mimage.PlotDataModel{
+					{
+						Rect:  image.Rect(10, 10, 50, 50),
+						Label: "Test Label 1",
+					},
+				},
+			},
+			ExpectedError: false,
+		},
 		{
 			Name: "Invalid image data",
 			Input: Input{
@@ -204,7 +256,7 @@ func TestPlotImageFromByte(t *testing.T) {
 				assert.NotZero(t, result)
 				_, typeImgResult, _ := image.Decode(bytes.NewBuffer(result))
 				_, typeImgSrc, _ := image.Decode(bytes.NewBuffer(tt.Input.Byte))
-				assert.Equal(t, typeImgSrc, typeImgResult)
+				assert.Equal(t, mimage.OutputFormat(typeImgSrc), typeImgResult)
 			}
 		})
 	}
@@ -269,7 +321,7 @@ func TestPlotImageFromDir(t *testing.T) {
 			ExpectedError: true,
 		},
 		{
-			Name: "Invalid image type",
+			Name: "Valid WEBP image data with plot data",
 			Input: Input{
 				FilePath: "../testdata/image_test.webp",
 				PlotData: []mimage.PlotDataModel{
@@ -279,7 +331,7 @@ func TestPlotImageFromDir(t *testing.T) {
 					},
 				},
 			},
-			ExpectedError: true,
+			ExpectedError: false,
 		},
 	}
 	for _, tt := range tests {
@@ -304,8 +356,113 @@ func TestPlotImageFromDir(t *testing.T) {
 				if err != nil {
 					t.Fatal(err)
 				}
-				assert.Equal(t, typeImgSrc, typeImgResult)
+				assert.Equal(t, mimage.OutputFormat(typeImgSrc), typeImgResult)
 			}
 		})
 	}
 }
+
+func TestPlotImageWithStyle(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Style    mimage.PlotStyle
+		Point    image.Point
+		Expected color.RGBA
+	}{
+		{
+			Name:     "Default color on border",
+			Style:    mimage.PlotStyle{},
+			Point:    image.Pt(10, 10),
+			Expected: color.RGBA{255, 0, 0, 255},
+		},
+		{
+			Name:     "Custom color on border",
+			Style:    mimage.PlotStyle{Color: color.RGBA{0, 255, 0, 255}},
+			Point:    image.Pt(10, 30),
+			Expected: color.RGBA{0, 255, 0, 255},
+		},
+		{
+			Name:     "Custom thickness",
+			Style:    mimage.PlotStyle{Color: color.RGBA{0, 0, 255, 255}, Thickness: 4},
+			Point:    image.Pt(13, 30),
+			Expected: color.RGBA{0, 0, 255, 255},
+		},
+		{
+			Name:     "Default thickness leaves inside untouched",
+			Style:    mimage.PlotStyle{},
+			Point:    image.Pt(13, 30),
+			Expected: color.RGBA{255, 255, 255, 255},
+		},
+		{
+			Name:     "Semi-transparent fill",
+			Style:    mimage.PlotStyle{FillColor: color.NRGBA{0, 0, 0, 128}},
+			Point:    image.Pt(30, 30),
+			Expected: color.RGBA{127, 127, 127, 255},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{
+				{
+					Rect:  image.Rect(10, 10, 50, 50),
+					Style: tt.Style,
+				},
+			})
+
+			// --------------- Assert ---------------
+			assert.NoError(t, err)
+			img, _, err := image.Decode(bytes.NewReader(result))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.Expected, color.RGBAModel.Convert(img.At(tt.Point.X, tt.Point.Y)))
+		})
+	}
+}
+
+func TestOutputFormat(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Input    string
+		Expected string
+	}{
+		{Name: "jpeg keeps jpeg", Input: "jpeg", Expected: "jpeg"},
+		{Name: "png keeps png", Input: "png", Expected: "png"},
+		{Name: "bmp keeps bmp", Input: "bmp", Expected: "bmp"},
+		{Name: "tiff keeps tiff", Input: "tiff", Expected: "tiff"},
+		{Name: "webp becomes png", Input: "webp", Expected: "png"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result := mimage.OutputFormat(tt.Input)
+
+			// --------------- Assert ---------------
+			assert.Equal(t, tt.Expected, result)
+		})
+	}
+}
+
+func TestPlotImageWithAvoidLabelOverlap(t *testing.T) {
+	// --------------- Arrange ---------------
+	plotData := make([]mimage.PlotDataModel, 30)
+	for i := range plotData {
+		x, y := 10+(i%6)*20, 30+(i/6)*20
+		plotData[i] = mimage.PlotDataModel{
+			Rect:  image.Rect(x, y, x+60, y+60),
+			Label: fmt.Sprintf("Person %d", i),
+			Style: mimage.PlotStyle{FontSize: 12},
+		}
+	}
+
+	// --------------- Act ---------------
+	first, err1 := mimage.PlotImageFromBytes(createTestImage("png"), plotData, mimage.WithAvoidLabelOverlap())
+	second, err2 := mimage.PlotImageFromBytes(createTestImage("png"), plotData, mimage.WithAvoidLabelOverlap())
+	plain, err3 := mimage.PlotImageFromBytes(createTestImage("png"), plotData)
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NoError(t, err3)
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, plain)
+}
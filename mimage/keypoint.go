@@ -0,0 +1,113 @@
+package mimage
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// จุด landmark หรือ keypoint 1 จุด
+type Keypoint struct {
+	At     image.Point
+	Score  float64 // ค่าความมั่นใจ 0-1 (0 คือไม่มีค่า)
+	Hidden bool    // จุดที่ถูกบังหรือไม่ได้ label ไว้ จะไม่ถูกวาด
+}
+
+// ชุดของ keypoint พร้อมเส้นเชื่อมระหว่างจุด เช่น landmark บนใบหน้าหรือ pose skeleton
+// ใช้เป็น PlotDataModel.Shape ได้ จึงวาดร่วมกับกรอบใบหน้าในภาพเดียวกันได้
+type Keypoints struct {
+	Points   []Keypoint
+	Skeleton [][2]int // เส้นเชื่อมเป็นคู่ index ใน Points เช่น COCOSkeleton
+	MinScore float64  // จุดที่มี Score น้อยกว่านี้จะไม่ถูกวาด รวมถึงเส้นที่เชื่อมกับจุดนั้น
+
+	Radius     int         // รัศมีของจุด (default: ความหนาเส้น * 2)
+	PointColor color.Color // สีของจุด (default: สีเส้นกรอบ)
+}
+
+// เส้นเชื่อมของ 17 keypoint แบบ COCO (จมูก, ตา, หู, ไหล่, ศอก, ข้อมือ, สะโพก, เข่า, ข้อเท้า)
+var COCOSkeleton = [][2]int{
+	{15, 13}, {13, 11}, {16, 14}, {14, 12}, {11, 12},
+	{5, 11}, {6, 12}, {5, 6}, {5, 7}, {6, 8}, {7, 9}, {8, 10},
+	{1, 2}, {0, 1}, {0, 2}, {1, 3}, {2, 4}, {3, 5}, {4, 6},
+}
+
+// เส้นเชื่อมของ 68 landmark บนใบหน้าแบบ iBUG 300-W (dlib)
+var Face68Skeleton = face68Skeleton()
+
+func face68Skeleton() [][2]int {
+	var edges [][2]int
+	chain := func(from, to int, closed bool) {
+		for i := from; i < to; i++ {
+			edges = append(edges, [2]int{i, i + 1})
+		}
+		if closed {
+			edges = append(edges, [2]int{to, from})
+		}
+	}
+	chain(0, 16, false)  // กรอบหน้า
+	chain(17, 21, false) // คิ้วขวา
+	chain(22, 26, false) // คิ้วซ้าย
+	chain(27, 30, false) // สันจมูก
+	chain(31, 35, false) // ปีกจมูก
+	chain(36, 41, true)  // ตาขวา
+	chain(42, 47, true)  // ตาซ้าย
+	chain(48, 59, true)  // ริมฝีปากด้านนอก
+	chain(60, 67, true)  // ริมฝีปากด้านใน
+	return edges
+}
+
+func (s Keypoints) visible(i int) bool {
+	if i < 0 || i >= len(s.Points) {
+		return false
+	}
+	p := s.Points[i]
+	return !p.Hidden && (p.Score == 0 || p.Score >= s.MinScore)
+}
+
+// ขอบเขตของจุดที่จะถูกวาด ถ้าไม่มีจุดไหนถูกวาดเลยจะคืน rect ที่กลับด้าน (Max < Min) เพื่อไม่ให้ถูกวาด
+func (s Keypoints) Bounds() image.Rectangle {
+	points := make([]image.Point, 0, len(s.Points))
+	for i, p := range s.Points {
+		if s.visible(i) {
+			points = append(points, p.At)
+		}
+	}
+	if len(points) == 0 {
+		return image.Rectangle{Max: image.Pt(-1, -1)}
+	}
+	return pointsBounds(points)
+}
+
+// คืนค่า Keypoints ชุดใหม่ที่แปลงตำแหน่งของทุกจุดแล้ว
+func (s Keypoints) Transform(t Transform) Shape {
+	points := make([]Keypoint, len(s.Points))
+	for i, p := range s.Points {
+		p.At = t.Point(p.At)
+		points[i] = p
+	}
+	s.Points = points
+	return s
+}
+
+func (s Keypoints) Draw(dst draw.Image, style PlotStyle) {
+	// วาดเส้นก่อนเพื่อให้จุดอยู่ด้านบน
+	for _, e := range s.Skeleton {
+		if s.visible(e[0]) && s.visible(e[1]) {
+			drawLine(dst, s.Points[e[0]].At, s.Points[e[1]].At, style.Thickness, style.Color)
+		}
+	}
+
+	r := s.Radius
+	if r <= 0 {
+		r = style.Thickness * 2
+	}
+	c := s.PointColor
+	if c == nil {
+		c = style.Color
+	}
+	for i, p := range s.Points {
+		if s.visible(i) {
+			drawRing(dst, p.At, r, r+1, c)
+		}
+	}
+}
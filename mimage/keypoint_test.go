@@ -0,0 +1,137 @@
+package mimage_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlotImageWithKeypoints(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	green := color.RGBA{0, 255, 0, 255}
+	white := color.RGBA{255, 255, 255, 255}
+	points := []mimage.Keypoint{
+		{At: image.Pt(40, 40)},
+		{At: image.Pt(160, 40), Score: 0.9},
+		{At: image.Pt(160, 160), Score: 0.2},
+		{At: image.Pt(40, 160), Hidden: true},
+	}
+	tests := []struct {
+		Name      string
+		Keypoints mimage.Keypoints
+		Points    map[image.Point]color.RGBA
+	}{
+		{
+			Name:      "Points without skeleton",
+			Keypoints: mimage.Keypoints{Points: points, Radius: 3, PointColor: green},
+			Points:    map[image.Point]color.RGBA{{40, 40}: green, {160, 40}: green, {160, 160}: green, {40, 160}: white, {100, 40}: white},
+		},
+		{
+			Name:      "Skeleton edges between visible points",
+			Keypoints: mimage.Keypoints{Points: points, Skeleton: [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 0}}},
+			Points:    map[image.Point]color.RGBA{{100, 40}: red, {160, 100}: red, {100, 160}: white, {40, 100}: white},
+		},
+		{
+			Name:      "Low score points and their edges are skipped",
+			Keypoints: mimage.Keypoints{Points: points, Skeleton: [][2]int{{0, 1}, {1, 2}}, MinScore: 0.5},
+			Points:    map[image.Point]color.RGBA{{100, 40}: red, {160, 100}: white, {160, 160}: white},
+		},
+		{
+			Name:      "Out of range skeleton index is ignored",
+			Keypoints: mimage.Keypoints{Points: points, Skeleton: [][2]int{{0, 9}}},
+			Points:    map[image.Point]color.RGBA{{40, 40}: red, {41, 60}: white},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{
+				{Shape: tt.Keypoints},
+			})
+
+			// --------------- Assert ---------------
+			assert.NoError(t, err)
+			img, _, err := image.Decode(bytes.NewReader(result))
+			assert.NoError(t, err)
+			for p, expected := range tt.Points {
+				assert.Equal(t, expected, color.RGBAModel.Convert(img.At(p.X, p.Y)), "pixel %v", p)
+			}
+		})
+	}
+}
+
+func TestKeypointsBounds(t *testing.T) {
+	// --------------- Arrange ---------------
+	kp := mimage.Keypoints{Points: []mimage.Keypoint{
+		{At: image.Pt(10, 50)},
+		{At: image.Pt(30, 20)},
+		{At: image.Pt(90, 90), Hidden: true},
+	}}
+
+	// --------------- Act ---------------
+	result := kp.Bounds()
+
+	// --------------- Assert ---------------
+	assert.Equal(t, image.Rect(10, 20, 30, 50), result)
+}
+
+func TestPlotImageKeypointsWithBox(t *testing.T) {
+	// --------------- Arrange ---------------
+	landmarks := make([]mimage.Keypoint, 68)
+	for i := range landmarks {
+		landmarks[i] = mimage.Keypoint{At: image.Pt(50+i, 100)}
+	}
+
+	// --------------- Act ---------------
+	result, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{
+		{Rect: image.Rect(40, 40, 160, 160), Label: "face"},
+		{Shape: mimage.Keypoints{Points: landmarks, Skeleton: mimage.Face68Skeleton}},
+		{Shape: mimage.Keypoints{Points: []mimage.Keypoint{{At: image.Pt(5, 5), Hidden: true}}}, Label: "hidden"},
+	})
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	img, _, err := image.Decode(bytes.NewReader(result))
+	assert.NoError(t, err)
+	assert.Equal(t, color.RGBA{255, 0, 0, 255}, color.RGBAModel.Convert(img.At(40, 100)))
+	assert.Equal(t, color.RGBA{255, 0, 0, 255}, color.RGBAModel.Convert(img.At(80, 100)))
+	// keypoint ที่ไม่มีจุดให้วาดจะไม่มี label ด้วย
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, color.RGBAModel.Convert(img.At(5, 1)))
+}
+
+func TestPlotImageHiddenKeypointsWithStrict(t *testing.T) {
+	// --------------- Arrange ---------------
+	hidden := mimage.Keypoints{Points: []mimage.Keypoint{{At: image.Pt(5, 5), Hidden: true}, {At: image.Pt(9, 9), Hidden: true}}}
+
+	// --------------- Act ---------------
+	_, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{
+		{Rect: image.Rect(40, 40, 160, 160)},
+		{Shape: hidden},
+	}, mimage.WithStrict())
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+}
+
+func TestSkeletonIndices(t *testing.T) {
+	for name, tt := range map[string]struct {
+		Skeleton [][2]int
+		Points   int
+		Edges    int
+	}{
+		"COCO":   {Skeleton: mimage.COCOSkeleton, Points: 17, Edges: 19},
+		"Face68": {Skeleton: mimage.Face68Skeleton, Points: 68, Edges: 63},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// --------------- Assert ---------------
+			assert.Len(t, tt.Skeleton, tt.Edges)
+			for _, e := range tt.Skeleton {
+				assert.True(t, e[0] >= 0 && e[0] < tt.Points && e[1] >= 0 && e[1] < tt.Points, "edge %v", e)
+			}
+		})
+	}
+}
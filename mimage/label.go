@@ -0,0 +1,263 @@
+package mimage
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"sort"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// ป้าย label ที่วัดขนาดและกำหนดตำแหน่งแล้ว
+type labelTag struct {
+	rect   image.Rectangle // พื้นที่ของป้าย
+	anchor image.Rectangle // กรอบที่เป็นเจ้าของ label
+	text   string
+	style  PlotStyle
+	pad    int
+	ascent int
+
+	displaced bool // ถูกเลื่อนออกจากตำแหน่งปกติเพื่อไม่ให้ทับ label อื่น
+}
+
+// วัดขนาดและหาตำแหน่งของทุก label ก่อน แล้วจึงวาดเส้นโยงและป้ายตามลำดับ
+func drawLabels(img draw.Image, o *options, labels []labelTag) error {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	// ใช้ face เดียวต่อขนาดตลอดการวาดภาพนี้
+	faces := make(map[float64]*fallbackFace)
+	defer func() {
+		for _, face := range faces {
+			o.fonts.release(face)
+		}
+	}()
+
+	bounds := img.Bounds()
+	tags := make([]labelTag, len(labels))
+	for i, l := range labels {
+		face, ok := faces[l.style.FontSize]
+		if !ok {
+			var err error
+			if face, err = o.fonts.acquire(l.style.FontSize); err != nil {
+				return err
+			}
+			faces[l.style.FontSize] = face
+		}
+		tags[i] = measureLabel(face, l.style, l.anchor, l.text)
+		tags[i].rect = placeLabel(bounds, l.anchor, tags[i].rect.Size())
+	}
+
+	if o.avoidLabelOverlap {
+		layoutLabels(bounds, tags)
+		for _, tag := range tags {
+			if tag.displaced {
+				from, to := nearestPoints(tag.rect, tag.anchor)
+				drawLine(img, from, to, 1, tag.style.LabelBackground)
+			}
+		}
+	}
+
+	for _, tag := range tags {
+		drawLabelTag(img, faces[tag.style.FontSize], tag)
+	}
+
+	return nil
+}
+
+// วัดขนาดป้ายจากตัวอักษรจริง โดยตำแหน่งเริ่มต้นอยู่เหนือมุมซ้ายบนของกรอบ
+func measureLabel(face font.Face, style PlotStyle, anchor image.Rectangle, text string) labelTag {
+	m := face.Metrics()
+	pad := max(1, int(math.Round(style.FontSize*0.2)))
+	ascent := m.Ascent.Ceil()
+	size := image.Pt(font.MeasureString(face, text).Ceil()+pad*2, ascent+m.Descent.Ceil()+pad*2)
+
+	return labelTag{
+		rect:   image.Rectangle{Max: size}.Add(image.Pt(anchor.Min.X, anchor.Min.Y-size.Y)),
+		anchor: anchor,
+		text:   text,
+		style:  style,
+		pad:    pad,
+		ascent: ascent,
+	}
+}
+
+// หาตำแหน่งป้ายที่ไม่ล้นภาพ: เหนือกรอบ -> ในกรอบด้านบน -> ใต้กรอบ แล้วเลื่อนให้อยู่ในภาพ
+func placeLabel(bounds, anchor image.Rectangle, size image.Point) image.Rectangle {
+	r := image.Rectangle{Max: size}
+	switch {
+	case anchor.Min.Y-size.Y >= bounds.Min.Y:
+		r = r.Add(image.Pt(anchor.Min.X, anchor.Min.Y-size.Y))
+	case size.Y <= anchor.Dy():
+		r = r.Add(image.Pt(anchor.Min.X, max(anchor.Min.Y, bounds.Min.Y)))
+	default:
+		r = r.Add(image.Pt(anchor.Min.X, anchor.Max.Y))
+	}
+	return clampRect(r, bounds)
+}
+
+// เลื่อน r ให้อยู่ใน bounds โดยไม่เปลี่ยนขนาด ถ้า r ใหญ่กว่า bounds จะชิดมุมซ้ายบน
+func clampRect(r, bounds image.Rectangle) image.Rectangle {
+	var d image.Point
+	if r.Max.X > bounds.Max.X {
+		d.X = bounds.Max.X - r.Max.X
+	}
+	if r.Min.X+d.X < bounds.Min.X {
+		d.X = bounds.Min.X - r.Min.X
+	}
+	if r.Max.Y > bounds.Max.Y {
+		d.Y = bounds.Max.Y - r.Max.Y
+	}
+	if r.Min.Y+d.Y < bounds.Min.Y {
+		d.Y = bounds.Min.Y - r.Min.Y
+	}
+	return r.Add(d)
+}
+
+func drawLabelTag(img draw.Image, face font.Face, tag labelTag) {
+	bg := tag.style.LabelBackground
+	textColor := tag.style.LabelColor
+	if textColor == nil {
+		// พื้นป้ายโปร่งแสงต้องดูสีของภาพด้านล่างด้วย
+		under := bg
+		if _, _, _, a := bg.RGBA(); a != 0xffff {
+			under = compositeOver(bg, averageColor(img, tag.rect))
+		}
+		textColor = contrastColor(under)
+	}
+
+	fillRect(img, tag.rect, bg)
+
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(textColor),
+		Face: face,
+		Dot:  fixed.P(tag.rect.Min.X+tag.pad, tag.rect.Min.Y+tag.pad+tag.ascent),
+	}
+	d.DrawString(tag.text)
+}
+
+// เลือกสีดำหรือขาวที่ตัดกับ bg มากกว่า โดยดูจากความสว่าง (luma)
+func contrastColor(bg color.Color) color.Color {
+	r, g, b, _ := bg.RGBA()
+	luma := (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 0xffff
+	if luma > 0.5 {
+		return color.Black
+	}
+	return color.White
+}
+
+// สีที่เห็นจริงเมื่อวาด c แบบโปร่งแสงทับบน under
+func compositeOver(c, under color.Color) color.Color {
+	sr, sg, sb, sa := c.RGBA()
+	ur, ug, ub, ua := under.RGBA()
+	a := 0xffff - sa
+	return color.RGBA64{
+		R: uint16(sr + ur*a/0xffff),
+		G: uint16(sg + ug*a/0xffff),
+		B: uint16(sb + ub*a/0xffff),
+		A: uint16(sa + ua*a/0xffff),
+	}
+}
+
+// ค่าเฉลี่ยสีของภาพในพื้นที่ r
+func averageColor(img image.Image, r image.Rectangle) color.Color {
+	r = r.Intersect(img.Bounds())
+	if r.Empty() {
+		return color.Transparent
+	}
+
+	var sr, sg, sb, sa, n uint64
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			cr, cg, cb, ca := img.At(x, y).RGBA()
+			sr, sg, sb, sa = sr+uint64(cr), sg+uint64(cg), sb+uint64(cb), sa+uint64(ca)
+			n++
+		}
+	}
+	return color.RGBA64{R: uint16(sr / n), G: uint16(sg / n), B: uint16(sb / n), A: uint16(sa / n)}
+}
+
+// เลื่อน label ที่ทับกับ label ก่อนหน้าไปยังตำแหน่งว่างที่ใกล้ที่สุด ตามลำดับของ input
+// ลองตำแหน่งปกติ ใต้กรอบ แล้วจึงขยับเป็นตารางรอบตำแหน่งปกติ (แนวนอนทีละครึ่งความกว้าง แนวตั้งทีละความสูงของป้าย)
+func layoutLabels(bounds image.Rectangle, tags []labelTag) {
+	placed := make([]image.Rectangle, 0, len(tags))
+	overlaps := func(r image.Rectangle) bool {
+		for _, p := range placed {
+			if r.Overlaps(p) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := range tags {
+		tag := &tags[i]
+		preferred := tag.rect
+		size := preferred.Size()
+
+		candidates := []image.Rectangle{
+			preferred,
+			clampRect(image.Rectangle{Max: size}.Add(image.Pt(tag.anchor.Min.X, tag.anchor.Max.Y)), bounds),
+		}
+		for _, off := range layoutOffsets(image.Pt(max(1, size.X/2), max(1, size.Y))) {
+			candidates = append(candidates, clampRect(preferred.Add(off), bounds))
+		}
+
+		for _, c := range candidates {
+			if !overlaps(c) {
+				tag.rect = c
+				break
+			}
+		}
+		tag.displaced = tag.rect != preferred && !adjacent(tag.rect, tag.anchor)
+		placed = append(placed, tag.rect)
+	}
+}
+
+// ระยะเลื่อนทั้งหมดในตารางขนาด step เรียงจากใกล้ไปไกล ถ้าระยะเท่ากันให้ขึ้นก่อนลง และซ้ายก่อนขวา
+func layoutOffsets(step image.Point) []image.Point {
+	const n = 12
+
+	offsets := make([]image.Point, 0, (2*n+1)*(2*n+1)-1)
+	for j := -n; j <= n; j++ {
+		for i := -n; i <= n; i++ {
+			if i != 0 || j != 0 {
+				offsets = append(offsets, image.Pt(i*step.X, j*step.Y))
+			}
+		}
+	}
+	sort.SliceStable(offsets, func(a, b int) bool {
+		da := offsets[a].X*offsets[a].X + offsets[a].Y*offsets[a].Y
+		db := offsets[b].X*offsets[b].X + offsets[b].Y*offsets[b].Y
+		return da < db
+	})
+	return offsets
+}
+
+// r และ s ติดกันพอดีโดยไม่ทับกัน
+func adjacent(r, s image.Rectangle) bool {
+	return r.Inset(-1).Overlaps(s)
+}
+
+// จุดบน a และ b ที่ใกล้กันที่สุด ใช้เป็นปลายของเส้นโยง label
+func nearestPoints(a, b image.Rectangle) (image.Point, image.Point) {
+	nearest := func(aMin, aMax, bMin, bMax int) (int, int) {
+		switch {
+		case aMax <= bMin:
+			return aMax - 1, bMin
+		case bMax <= aMin:
+			return aMin, bMax - 1
+		default:
+			mid := (max(aMin, bMin) + min(aMax, bMax)) / 2
+			return mid, mid
+		}
+	}
+	ax, bx := nearest(a.Min.X, a.Max.X, b.Min.X, b.Max.X)
+	ay, by := nearest(a.Min.Y, a.Max.Y, b.Min.Y, b.Max.Y)
+	return image.Pt(ax, ay), image.Pt(bx, by)
+}
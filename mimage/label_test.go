@@ -0,0 +1,210 @@
+package mimage
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaceLabel(t *testing.T) {
+	bounds := image.Rect(0, 0, 200, 100)
+	size := image.Pt(40, 12)
+	tests := []struct {
+		Name     string
+		Anchor   image.Rectangle
+		Expected image.Rectangle
+	}{
+		{
+			Name:     "Above the box",
+			Anchor:   image.Rect(10, 50, 60, 90),
+			Expected: image.Rect(10, 38, 50, 50),
+		},
+		{
+			Name:     "Inside the box at the top edge",
+			Anchor:   image.Rect(10, 0, 60, 40),
+			Expected: image.Rect(10, 0, 50, 12),
+		},
+		{
+			Name:     "Inside the box that starts above the image",
+			Anchor:   image.Rect(10, -20, 60, 40),
+			Expected: image.Rect(10, 0, 50, 12),
+		},
+		{
+			Name:     "Below a short box at the top edge",
+			Anchor:   image.Rect(10, 2, 60, 10),
+			Expected: image.Rect(10, 10, 50, 22),
+		},
+		{
+			Name:     "Shift left at the right edge",
+			Anchor:   image.Rect(180, 50, 200, 90),
+			Expected: image.Rect(160, 38, 200, 50),
+		},
+		{
+			Name:     "Shift right at the left edge",
+			Anchor:   image.Rect(-10, 50, 30, 90),
+			Expected: image.Rect(0, 38, 40, 50),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result := placeLabel(bounds, tt.Anchor, size)
+
+			// --------------- Assert ---------------
+			assert.Equal(t, tt.Expected, result)
+		})
+	}
+}
+
+func TestContrastColor(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Input    color.Color
+		Expected color.Color
+	}{
+		{Name: "Yellow background", Input: color.RGBA{255, 255, 0, 255}, Expected: color.Black},
+		{Name: "White background", Input: color.White, Expected: color.Black},
+		{Name: "Red background", Input: color.RGBA{255, 0, 0, 255}, Expected: color.White},
+		{Name: "Navy background", Input: color.RGBA{0, 0, 128, 255}, Expected: color.White},
+		{Name: "Translucent black over white", Input: compositeOver(color.NRGBA{0, 0, 0, 40}, color.White), Expected: color.Black},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result := contrastColor(tt.Input)
+
+			// --------------- Assert ---------------
+			assert.Equal(t, tt.Expected, result)
+		})
+	}
+}
+
+func TestDrawLabel(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Style     PlotStyle
+		Anchor    image.Rectangle
+		TextColor color.RGBA
+	}{
+		{
+			Name:      "Yellow tag uses black text",
+			Style:     PlotStyle{Color: color.RGBA{255, 255, 0, 255}, FontSize: 16},
+			Anchor:    image.Rect(20, 60, 150, 150),
+			TextColor: color.RGBA{0, 0, 0, 255},
+		},
+		{
+			Name:      "Blue tag at top edge uses white text",
+			Style:     PlotStyle{Color: color.RGBA{0, 0, 255, 255}, FontSize: 16},
+			Anchor:    image.Rect(20, 0, 150, 150),
+			TextColor: color.RGBA{255, 255, 255, 255},
+		},
+		{
+			Name:      "Explicit label color",
+			Style:     PlotStyle{Color: color.RGBA{0, 0, 255, 255}, FontSize: 16, LabelColor: color.RGBA{0, 255, 0, 255}},
+			Anchor:    image.Rect(20, 60, 150, 150),
+			TextColor: color.RGBA{0, 255, 0, 255},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Arrange ---------------
+			img := image.NewRGBA(image.Rect(0, 0, 200, 200))
+			draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{128, 128, 128, 255}), image.Point{}, draw.Src)
+			style := tt.Style.resolve(tt.Anchor)
+
+			// --------------- Act ---------------
+			err := drawLabels(img, newOptions(nil), []labelTag{{anchor: tt.Anchor, text: "Face 01", style: style}})
+
+			// --------------- Assert ---------------
+			assert.NoError(t, err)
+			face, _ := DefaultFonts.acquire(style.FontSize)
+			defer DefaultFonts.release(face)
+			tag := measureLabel(face, style, tt.Anchor, "Face 01")
+			tag.rect = placeLabel(img.Bounds(), tt.Anchor, tag.rect.Size())
+			assert.True(t, tag.rect.In(img.Bounds()))
+
+			counts := map[color.RGBA]int{}
+			for y := tag.rect.Min.Y; y < tag.rect.Max.Y; y++ {
+				for x := tag.rect.Min.X; x < tag.rect.Max.X; x++ {
+					counts[img.RGBAAt(x, y)]++
+				}
+			}
+			assert.Positive(t, counts[color.RGBAModel.Convert(style.LabelBackground).(color.RGBA)])
+			assert.Positive(t, counts[tt.TextColor])
+			assert.Zero(t, counts[color.RGBA{128, 128, 128, 255}])
+		})
+	}
+}
+
+func TestLayoutLabels(t *testing.T) {
+	bounds := image.Rect(0, 0, 400, 300)
+	newTags := func() []labelTag {
+		// กรอบ 30 อันที่ซ้อนกันเป็นกลุ่ม ทำให้ป้ายตำแหน่งปกติทับกัน
+		tags := make([]labelTag, 30)
+		for i := range tags {
+			anchor := image.Rect(20+(i%6)*10, 40+(i/6)*8, 120+(i%6)*10, 140+(i/6)*8)
+			tags[i] = labelTag{anchor: anchor, rect: placeLabel(bounds, anchor, image.Pt(50, 14))}
+		}
+		return tags
+	}
+
+	// --------------- Act ---------------
+	first := newTags()
+	layoutLabels(bounds, first)
+	second := newTags()
+	layoutLabels(bounds, second)
+
+	// --------------- Assert ---------------
+	assert.Equal(t, first, second)
+	for i := range first {
+		assert.True(t, first[i].rect.In(bounds))
+		for j := i + 1; j < len(first); j++ {
+			assert.False(t, first[i].rect.Overlaps(first[j].rect), "label %d overlaps label %d", i, j)
+		}
+	}
+	assert.False(t, first[0].displaced)
+	displaced := 0
+	for _, tag := range first {
+		if tag.displaced {
+			displaced++
+		}
+	}
+	assert.Positive(t, displaced)
+}
+
+func TestNearestPoints(t *testing.T) {
+	tests := []struct {
+		Name      string
+		A, B      image.Rectangle
+		ExpectedA image.Point
+		ExpectedB image.Point
+	}{
+		{
+			Name:      "A above B",
+			A:         image.Rect(10, 0, 30, 10),
+			B:         image.Rect(0, 20, 40, 40),
+			ExpectedA: image.Pt(20, 9),
+			ExpectedB: image.Pt(20, 20),
+		},
+		{
+			Name:      "A left of and above B",
+			A:         image.Rect(0, 0, 10, 10),
+			B:         image.Rect(20, 20, 40, 40),
+			ExpectedA: image.Pt(9, 9),
+			ExpectedB: image.Pt(20, 20),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			a, b := nearestPoints(tt.A, tt.B)
+
+			// --------------- Assert ---------------
+			assert.Equal(t, tt.ExpectedA, a)
+			assert.Equal(t, tt.ExpectedB, b)
+		})
+	}
+}
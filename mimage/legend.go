@@ -0,0 +1,145 @@
+package mimage
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"sort"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// ตำแหน่งของ legend
+type LegendPosition int
+
+const (
+	LegendNone LegendPosition = iota
+	LegendTopLeft
+	LegendTopRight
+	LegendBottomLeft
+	LegendBottomRight
+	LegendStrip // แถบต่อท้ายด้านล่างของภาพ ภาพผลลัพธ์จะสูงขึ้นตามจำนวนแถวของ legend
+)
+
+var (
+	legendPanelColor = color.NRGBA{0, 0, 0, 180}
+	legendStripColor = color.RGBA{32, 32, 32, 255}
+)
+
+type legendItem struct {
+	class string
+	color color.Color
+	count int
+}
+
+// รวมจำนวนกรอบของแต่ละ class เรียงจากจำนวนมากไปน้อย ถ้าเท่ากันเรียงตามชื่อ
+func legendItems(plots []PlotDataModel) []legendItem {
+	index := make(map[string]int)
+	items := make([]legendItem, 0)
+	for _, p := range plots {
+		if p.Class == "" {
+			continue
+		}
+		if i, ok := index[p.Class]; ok {
+			items[i].count++
+			continue
+		}
+		c := p.Style.Color
+		if c == nil {
+			c = defaultColor
+		}
+		index[p.Class] = len(items)
+		items = append(items, legendItem{class: p.Class, color: c, count: 1})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].count != items[j].count {
+			return items[i].count > items[j].count
+		}
+		return items[i].class < items[j].class
+	})
+	return items
+}
+
+func drawLegend(img *image.RGBA, o *options, items []legendItem) (*image.RGBA, error) {
+	if len(items) == 0 {
+		return img, nil
+	}
+
+	// ขนาดตัวอักษรตามขนาดภาพ อย่างน้อย 12px
+	b := img.Bounds()
+	face, err := o.fonts.acquire(max(12, float64(min(b.Dx(), b.Dy()))/40))
+	if err != nil {
+		return nil, err
+	}
+	defer o.fonts.release(face)
+
+	m := face.Metrics()
+	lineHeight := (m.Ascent + m.Descent).Ceil()
+	pad := max(2, lineHeight/3)
+
+	texts := make([]string, len(items))
+	widths := make([]int, len(items))
+	for i, item := range items {
+		texts[i] = fmt.Sprintf("%s (%d)", item.class, item.count)
+		widths[i] = lineHeight + pad + font.MeasureString(face, texts[i]).Ceil()
+	}
+
+	// หาตำแหน่งของแต่ละรายการ
+	positions := make([]image.Point, len(items))
+	var panel image.Rectangle
+	if o.legend == LegendStrip {
+		x, y := pad, pad
+		for i, w := range widths {
+			if x > pad && x+w > b.Dx()-pad {
+				x, y = pad, y+lineHeight+pad
+			}
+			positions[i] = image.Pt(x, y)
+			x += w + pad*3
+		}
+		panel = image.Rect(0, 0, b.Dx(), y+lineHeight+pad).Add(image.Pt(b.Min.X, b.Max.Y))
+
+		out := image.NewRGBA(image.Rect(b.Min.X, b.Min.Y, b.Max.X, panel.Max.Y))
+		draw.Draw(out, b, img, b.Min, draw.Src)
+		fillRect(out, panel, legendStripColor)
+		img = out
+	} else {
+		width := 0
+		for i, w := range widths {
+			positions[i] = image.Pt(pad, pad+i*(lineHeight+pad))
+			width = max(width, w)
+		}
+		size := image.Pt(width+pad*2, len(items)*(lineHeight+pad)+pad)
+
+		var min image.Point
+		switch o.legend {
+		case LegendTopRight:
+			min = image.Pt(b.Max.X-size.X-pad, b.Min.Y+pad)
+		case LegendBottomLeft:
+			min = image.Pt(b.Min.X+pad, b.Max.Y-size.Y-pad)
+		case LegendBottomRight:
+			min = image.Pt(b.Max.X-size.X-pad, b.Max.Y-size.Y-pad)
+		default:
+			min = image.Pt(b.Min.X+pad, b.Min.Y+pad)
+		}
+		panel = clampRect(image.Rectangle{Min: min, Max: min.Add(size)}, b)
+		fillRect(img, panel, legendPanelColor)
+	}
+
+	for i, item := range items {
+		p := panel.Min.Add(positions[i])
+		fillRect(img, image.Rect(p.X, p.Y, p.X+lineHeight, p.Y+lineHeight), item.color)
+
+		d := &font.Drawer{
+			Dst:  img,
+			Src:  image.White,
+			Face: face,
+			Dot:  fixed.P(p.X+lineHeight+pad, p.Y+m.Ascent.Ceil()),
+		}
+		d.DrawString(texts[i])
+	}
+
+	return img, nil
+}
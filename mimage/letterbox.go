@@ -0,0 +1,89 @@
+package mimage
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// สีขอบ default ของ Letterbox (เทาแบบเดียวกับที่ YOLO ใช้)
+var LetterboxColor = color.RGBA{114, 114, 114, 255}
+
+// ความสัมพันธ์ระหว่างพิกัดบนภาพต้นฉบับกับภาพ letterbox ที่ได้จาก Letterbox
+// Transform แปลงจากภาพต้นฉบับไปเป็นภาพ letterbox
+type LetterboxTransform struct {
+	Transform
+	Source image.Point // ขนาดของภาพต้นฉบับ
+	Size   image.Point // ขนาดของภาพ letterbox
+}
+
+// สำหรับย่อขยาย img ให้อยู่ใน width x height โดยคงสัดส่วน แล้วเติมขอบด้วยสี fill ให้ภาพอยู่ตรงกลาง
+// (default fill: LetterboxColor) ใช้ bilinear interpolation
+// คืนค่า LetterboxTransform สำหรับแปลงกรอบที่ model ตอบกลับมาให้เป็นพิกัดบนภาพต้นฉบับ
+func Letterbox(img image.Image, width, height int, fill color.Color) (*image.RGBA, LetterboxTransform) {
+	if fill == nil {
+		fill = LetterboxColor
+	}
+	src := img.Bounds()
+	scale := math.Min(float64(width)/float64(src.Dx()), float64(height)/float64(src.Dy()))
+	size := image.Pt(max(int(math.Round(float64(src.Dx())*scale)), 1), max(int(math.Round(float64(src.Dy())*scale)), 1))
+	offset := image.Pt((width-size.X)/2, (height-size.Y)/2)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(fill), image.Point{}, draw.Src)
+	xdraw.BiLinear.Scale(dst, image.Rectangle{Min: offset, Max: offset.Add(size)}, img, src, draw.Src, nil)
+
+	return dst, LetterboxTransform{
+		Transform: Transform{
+			ScaleX:  float64(size.X) / float64(src.Dx()),
+			ScaleY:  float64(size.Y) / float64(src.Dy()),
+			OffsetX: float64(offset.X) - float64(src.Min.X)*float64(size.X)/float64(src.Dx()),
+			OffsetY: float64(offset.Y) - float64(src.Min.Y)*float64(size.Y)/float64(src.Dy()),
+		},
+		Source: src.Size(),
+		Size:   image.Pt(width, height),
+	}
+}
+
+// สำหรับแปลงกรอบจากพิกัดบนภาพ letterbox กลับเป็นพิกัดบนภาพต้นฉบับ เพื่อส่งต่อให้ PlotImage
+// Box แบบสัดส่วนถูกคิดเทียบกับขนาดของภาพ letterbox และจะได้ผลลัพธ์เป็น Box แบบ pixel
+// Rect ที่ Max เป็น pixel สุดท้ายของภาพ letterbox จะได้ Max เป็น pixel สุดท้ายของภาพต้นฉบับ
+func (t LetterboxTransform) Unmap(plots []PlotDataModel) []PlotDataModel {
+	out := make([]PlotDataModel, len(plots))
+	for i, p := range plots {
+		if p.Box != nil && p.Box.Normalized {
+			box := p.Box.Denormalize(t.Size)
+			p.Box = &box
+		}
+		out[i] = p
+	}
+	return t.Invert().Plots(out)
+}
+
+// สำหรับแปลงกรอบจากพิกัดบนภาพต้นฉบับไปเป็นพิกัดบนภาพ letterbox
+// Box แบบสัดส่วนถูกคิดเทียบกับขนาดของภาพต้นฉบับ และจะได้ผลลัพธ์เป็น Box แบบ pixel
+func (t LetterboxTransform) Map(plots []PlotDataModel) []PlotDataModel {
+	out := make([]PlotDataModel, len(plots))
+	for i, p := range plots {
+		if p.Box != nil && p.Box.Normalized {
+			box := p.Box.Denormalize(t.Source)
+			p.Box = &box
+		}
+		out[i] = p
+	}
+	return t.Plots(out)
+}
+
+// สำหรับแปลง keypoint จากพิกัดบนภาพ letterbox กลับเป็นพิกัดบนภาพต้นฉบับ
+func (t LetterboxTransform) UnmapKeypoints(points []Keypoint) []Keypoint {
+	inv := t.Invert()
+	out := make([]Keypoint, len(points))
+	for i, p := range points {
+		p.At = inv.Point(p.At)
+		out[i] = p
+	}
+	return out
+}
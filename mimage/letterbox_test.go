@@ -0,0 +1,72 @@
+package mimage_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLetterbox(t *testing.T) {
+	// --------------- Act ---------------
+	img, tr := mimage.Letterbox(createSplitImage(), 640, 640, nil)
+
+	// --------------- Assert ---------------
+	assert.Equal(t, image.Rect(0, 0, 640, 640), img.Bounds())
+	assert.Equal(t, image.Pt(200, 100), tr.Source)
+	assert.Equal(t, image.Pt(640, 640), tr.Size)
+	assert.Equal(t, mimage.Transform{ScaleX: 3.2, ScaleY: 3.2, OffsetX: 0, OffsetY: 160}, tr.Transform)
+	assert.Equal(t, mimage.LetterboxColor, img.RGBAAt(320, 100))
+	assert.Equal(t, mimage.LetterboxColor, img.RGBAAt(320, 600))
+	assert.Equal(t, color.RGBA{255, 0, 0, 255}, img.RGBAAt(100, 320))
+	assert.Equal(t, color.RGBA{0, 0, 255, 255}, img.RGBAAt(540, 320))
+}
+
+func TestLetterboxUnmap(t *testing.T) {
+	// --------------- Arrange ---------------
+	_, tr := mimage.Letterbox(createSplitImage(), 640, 640, color.Black)
+	normalized := mimage.NewBox(mimage.FormatCXCYWH, true, 0.5, 0.5, 0.25, 0.125)
+	plots := []mimage.PlotDataModel{
+		{Rect: image.Rect(64, 224, 319, 415), Class: "face", Score: 0.9},
+		{Box: &normalized},
+		{Shape: mimage.Keypoints{Points: []mimage.Keypoint{{At: image.Pt(320, 320)}}}},
+	}
+
+	// --------------- Act ---------------
+	result := tr.Unmap(plots)
+	keypoints := tr.UnmapKeypoints([]mimage.Keypoint{{At: image.Pt(640, 480), Score: 0.5}})
+	roundTrip := tr.Map(result)
+
+	// --------------- Assert ---------------
+	assert.Equal(t, image.Rect(20, 20, 99, 79), result[0].Rect)
+	assert.Equal(t, "face", result[0].Class)
+	assert.Equal(t, image.Rect(75, 38, 124, 62), result[1].Box.Rect(image.Rect(0, 0, 200, 100)))
+	assert.Equal(t, image.Pt(100, 50), result[2].Shape.(mimage.Keypoints).Points[0].At)
+	assert.Equal(t, []mimage.Keypoint{{At: image.Pt(200, 100), Score: 0.5}}, keypoints)
+	assert.Equal(t, plots[0].Rect, roundTrip[0].Rect)
+	assert.Equal(t, image.Rect(240, 280, 399, 359), roundTrip[1].Box.Rect(image.Rect(0, 0, 640, 640)))
+	assert.True(t, normalized.Normalized)
+}
+
+func TestLetterboxUnmapCorner(t *testing.T) {
+	// --------------- Arrange ---------------
+	src := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	_, tr := mimage.Letterbox(src, 640, 640, nil)
+	plots := []mimage.PlotDataModel{{Rect: image.Rect(0, 0, 639, 639)}}
+
+	// --------------- Act ---------------
+	result := tr.Unmap(plots)
+	roundTrip := tr.Map(result)
+	buf := new(bytes.Buffer)
+	png.Encode(buf, src)
+	_, err := mimage.PlotImageFromBytes(buf.Bytes(), result, mimage.WithStrict())
+
+	// --------------- Assert ---------------
+	assert.Equal(t, image.Rect(0, 0, 99, 99), result[0].Rect)
+	assert.Equal(t, plots[0].Rect, roundTrip[0].Rect)
+	assert.NoError(t, err)
+}
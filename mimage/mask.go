@@ -0,0 +1,146 @@
+package mimage
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+const defaultMaskOpacity = 0.5
+
+// mask ของ segmentation ที่ระบายทับภาพแบบโปร่งแสง กำหนดผ่าน PlotDataModel.Mask
+// Data เป็นได้ทั้ง binary mask (0 กับค่าอื่น) หรือ class map ที่แต่ละค่าคือ class id โดย 0 คือพื้นหลังเสมอ
+type Mask struct {
+	Data *image.Gray
+
+	// ตำแหน่งบนภาพที่ mask จะถูกยืดไปวาง (nearest neighbor)
+	// default: Rect ของกรอบถ้ามี (mask แบบ box-relative) ไม่เช่นนั้นจะใช้ทั้งภาพ
+	Rect image.Rectangle
+
+	Colors  map[uint8]color.Color // สีของแต่ละค่าใน Data (default: สีเส้นกรอบ)
+	Opacity float64               // ความทึบ 0-1 (default: 0.5)
+	Contour bool                  // วาดเส้นขอบของแต่ละบริเวณด้วยสีทึบ
+}
+
+// วาด mask ของทุกกรอบก่อนวาดเส้นกรอบและ label เพื่อไม่ให้สีของ mask ทับเส้น
+func drawMasks(img draw.Image, plots []PlotDataModel) {
+	for _, p := range plots {
+		if p.Mask == nil || p.Mask.Data == nil {
+			continue
+		}
+		target := p.Mask.Rect
+		if target.Empty() && p.Rect != (image.Rectangle{}) {
+			// ครอบถึง pixel ที่ Max ของกรอบเหมือนเส้นกรอบ
+			target = inclusive(p.Rect.Canon())
+		}
+		if target.Empty() {
+			target = img.Bounds()
+		}
+		p.Mask.draw(img, target, p.Style.resolve(target).Color)
+	}
+}
+
+// ขอบเขตบนภาพของ pixel ที่ไม่ใช่พื้นหลัง (ค่า 0) เมื่อยืด Data ไปวางที่ target
+func (m *Mask) extent(target image.Rectangle) image.Rectangle {
+	src := m.Data.Bounds()
+	if target.Empty() || src.Empty() {
+		return image.Rectangle{}
+	}
+	var r image.Rectangle
+	for y := src.Min.Y; y < src.Max.Y; y++ {
+		for x := src.Min.X; x < src.Max.X; x++ {
+			if m.Data.GrayAt(x, y).Y != 0 {
+				r = r.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	if r.Empty() {
+		return r
+	}
+
+	// pixel x บนภาพได้ค่าจาก Data ที่ (x-target.Min.X)*src.Dx()/target.Dx() (ปัดลง)
+	// ขอบของ pixel ใน Data บนภาพจึงคำนวณด้วยการปัดขึ้น
+	edge := func(v, from, to, srcSize, dstSize int) int {
+		return to + ((v-from)*dstSize+srcSize-1)/srcSize
+	}
+	return image.Rect(
+		edge(r.Min.X, src.Min.X, target.Min.X, src.Dx(), target.Dx()),
+		edge(r.Min.Y, src.Min.Y, target.Min.Y, src.Dy(), target.Dy()),
+		edge(r.Max.X, src.Min.X, target.Min.X, src.Dx(), target.Dx()),
+		edge(r.Max.Y, src.Min.Y, target.Min.Y, src.Dy(), target.Dy()),
+	)
+}
+
+func (m *Mask) draw(dst draw.Image, target image.Rectangle, fallback color.Color) {
+	area := target.Intersect(dst.Bounds())
+	src := m.Data.Bounds()
+	if area.Empty() || src.Empty() {
+		return
+	}
+
+	opacity := m.Opacity
+	if opacity <= 0 {
+		opacity = defaultMaskOpacity
+	}
+	solid := make(map[uint8]color.Color)
+	fills := make(map[uint8]color.Color)
+	colorOf := func(v uint8) (fill, edge color.Color) {
+		if c, ok := fills[v]; ok {
+			return c, solid[v]
+		}
+		c, ok := m.Colors[v]
+		if !ok {
+			c = fallback
+		}
+		n := color.NRGBAModel.Convert(c).(color.NRGBA)
+		solid[v] = n
+		n.A = uint8(float64(n.A)*min(opacity, 1) + 0.5)
+		fills[v] = n
+		return n, solid[v]
+	}
+
+	// ค่าของ mask ที่ตำแหน่ง x, y บนภาพ (0 ถ้าอยู่นอก target)
+	at := func(x, y int) uint8 {
+		if !(image.Point{x, y}.In(target)) {
+			return 0
+		}
+		sx := src.Min.X + (x-target.Min.X)*src.Dx()/target.Dx()
+		sy := src.Min.Y + (y-target.Min.Y)*src.Dy()/target.Dy()
+		return m.Data.GrayAt(sx, sy).Y
+	}
+
+	// ระบายทีละช่วงของค่าเดียวกันในแต่ละแถว
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		start, v := area.Min.X, at(area.Min.X, y)
+		for x := area.Min.X + 1; x <= area.Max.X; x++ {
+			next := uint8(0)
+			if x < area.Max.X {
+				next = at(x, y)
+			}
+			if x < area.Max.X && next == v {
+				continue
+			}
+			if v != 0 {
+				fill, _ := colorOf(v)
+				fillRect(dst, image.Rect(start, y, x, y+1), fill)
+			}
+			start, v = x, next
+		}
+	}
+
+	if !m.Contour {
+		return
+	}
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			v := at(x, y)
+			if v == 0 {
+				continue
+			}
+			if at(x-1, y) != v || at(x+1, y) != v || at(x, y-1) != v || at(x, y+1) != v {
+				_, edge := colorOf(v)
+				fillRect(dst, image.Rect(x, y, x+1, y+1), edge)
+			}
+		}
+	}
+}
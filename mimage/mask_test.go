@@ -0,0 +1,119 @@
+package mimage_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+// mask ขนาด w x h ที่ครึ่งซ้ายเป็นค่า left และครึ่งขวาเป็นค่า right
+func createTestMask(w, h int, left, right uint8) *image.Gray {
+	m := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if x < w/2 {
+				m.SetGray(x, y, color.Gray{left})
+			} else {
+				m.SetGray(x, y, color.Gray{right})
+			}
+		}
+	}
+	return m
+}
+
+func TestPlotImageWithMask(t *testing.T) {
+	white := color.RGBA{255, 255, 255, 255}
+	tests := []struct {
+		Name   string
+		Plot   mimage.PlotDataModel
+		Points map[image.Point]color.RGBA
+	}{
+		{
+			Name: "Full image binary mask uses stroke color",
+			Plot: mimage.PlotDataModel{Mask: &mimage.Mask{Data: createTestMask(200, 200, 0, 255)}},
+			Points: map[image.Point]color.RGBA{
+				{50, 100}:  white,
+				{150, 100}: {255, 127, 127, 255},
+			},
+		},
+		{
+			Name: "Box relative mask is scaled into the box",
+			Plot: mimage.PlotDataModel{
+				Rect:  image.Rect(100, 100, 180, 180),
+				Style: mimage.PlotStyle{Color: color.RGBA{0, 0, 255, 255}},
+				Mask:  &mimage.Mask{Data: createTestMask(4, 4, 0, 1), Opacity: 1},
+			},
+			Points: map[image.Point]color.RGBA{
+				{120, 140}: white,
+				{140, 140}: white,
+				{141, 140}: {0, 0, 255, 255},
+				{160, 140}: {0, 0, 255, 255},
+				{100, 140}: {0, 0, 255, 255},
+				{190, 140}: white,
+			},
+		},
+		{
+			Name: "Class map with per class colors",
+			Plot: mimage.PlotDataModel{Mask: &mimage.Mask{
+				Data:    createTestMask(10, 10, 1, 2),
+				Rect:    image.Rect(0, 0, 100, 100),
+				Colors:  map[uint8]color.Color{1: color.RGBA{0, 255, 0, 255}, 2: color.RGBA{0, 0, 255, 255}},
+				Opacity: 1,
+			}},
+			Points: map[image.Point]color.RGBA{
+				{25, 50}:  {0, 255, 0, 255},
+				{75, 50}:  {0, 0, 255, 255},
+				{150, 50}: white,
+			},
+		},
+		{
+			Name: "Contour is drawn with solid color",
+			Plot: mimage.PlotDataModel{Mask: &mimage.Mask{
+				Data:    createTestMask(200, 200, 0, 1),
+				Colors:  map[uint8]color.Color{1: color.RGBA{0, 0, 0, 255}},
+				Opacity: 0.2,
+				Contour: true,
+			}},
+			Points: map[image.Point]color.RGBA{
+				{100, 100}: {0, 0, 0, 255},
+				{199, 100}: {0, 0, 0, 255},
+				{150, 100}: {204, 204, 204, 255},
+				{50, 100}:  white,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{tt.Plot})
+
+			// --------------- Assert ---------------
+			assert.NoError(t, err)
+			img, _, err := image.Decode(bytes.NewReader(result))
+			assert.NoError(t, err)
+			for p, expected := range tt.Points {
+				assert.Equal(t, expected, color.RGBAModel.Convert(img.At(p.X, p.Y)), "pixel %v", p)
+			}
+		})
+	}
+}
+
+func TestPlotImageMaskWithClassFilter(t *testing.T) {
+	// --------------- Act ---------------
+	result, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{
+		{Class: "road", Mask: &mimage.Mask{Data: createTestMask(200, 200, 1, 1)}},
+		{Class: "car", Rect: image.Rect(20, 20, 80, 80), Mask: &mimage.Mask{Data: createTestMask(2, 2, 1, 1), Opacity: 1}},
+	}, mimage.WithClasses("car"))
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	img, _, err := image.Decode(bytes.NewReader(result))
+	assert.NoError(t, err)
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, color.RGBAModel.Convert(img.At(150, 150)))
+	// mask ใช้สีเดียวกับกรอบของ class จาก palette
+	assert.Equal(t, color.RGBAModel.Convert(img.At(20, 50)), color.RGBAModel.Convert(img.At(50, 50)))
+}
@@ -0,0 +1,91 @@
+package mimage
+
+import (
+	"errors"
+	"image"
+	"image/draw"
+	"math"
+)
+
+// error เมื่อจำนวนมุมที่ส่งให้ OrientedBoxFromCorners ไม่เท่ากับ 4
+var ErrCornerCount = errors.New("oriented box must have 4 corners")
+
+// กรอบที่หมุนได้ (oriented bounding box) เช่นเอกสารหรือป้ายทะเบียนที่เอียง
+// Angle เป็นองศา ค่าบวกหมุนตามเข็มนาฬิกาบนภาพ (แกน y ชี้ลง)
+// ใช้เป็น PlotDataModel.Shape ได้ โดยวาดด้วยเส้นกรอบ, FillColor และ label แบบเดียวกับกรอบปกติ
+type OrientedBox struct {
+	CX, CY        float64 // จุดกึ่งกลาง
+	Width, Height float64
+	Angle         float64
+}
+
+// สำหรับสร้าง OrientedBox ที่ไม่หมุนจาก image.Rectangle
+func OrientedBoxFromRect(r image.Rectangle) OrientedBox {
+	r = r.Canon()
+	return OrientedBox{
+		CX:     float64(r.Min.X+r.Max.X) / 2,
+		CY:     float64(r.Min.Y+r.Max.Y) / 2,
+		Width:  float64(r.Dx()),
+		Height: float64(r.Dy()),
+	}
+}
+
+// สำหรับสร้าง OrientedBox จากมุมทั้ง 4 เรียงตามลำดับรอบรูป (เช่นจาก Corners)
+// ความกว้างและมุมคิดจากด้านแรก (มุมที่ 1 ไปมุมที่ 2) ความสูงคิดจากด้านที่สอง
+func OrientedBoxFromCorners(corners []image.Point) (OrientedBox, error) {
+	if len(corners) != 4 {
+		return OrientedBox{}, ErrCornerCount
+	}
+
+	var cx, cy float64
+	for _, c := range corners {
+		cx += float64(c.X) / 4
+		cy += float64(c.Y) / 4
+	}
+	p0, p1, p2 := corners[0], corners[1], corners[2]
+	return OrientedBox{
+		CX:     cx,
+		CY:     cy,
+		Width:  math.Hypot(float64(p1.X-p0.X), float64(p1.Y-p0.Y)),
+		Height: math.Hypot(float64(p2.X-p1.X), float64(p2.Y-p1.Y)),
+		Angle:  math.Atan2(float64(p1.Y-p0.Y), float64(p1.X-p0.X)) * 180 / math.Pi,
+	}, nil
+}
+
+// คืนค่ามุมทั้ง 4 เรียงจากมุมซ้ายบน, ขวาบน, ขวาล่าง, ซ้ายล่าง (ก่อนหมุน) ปัดเศษเป็น pixel ที่ใกล้ที่สุด
+func (b OrientedBox) Corners() []image.Point {
+	rad := b.Angle * math.Pi / 180
+	sin, cos := math.Sincos(rad)
+	hw, hh := b.Width/2, b.Height/2
+
+	corners := make([]image.Point, 4)
+	for i, d := range [4][2]float64{{-hw, -hh}, {hw, -hh}, {hw, hh}, {-hw, hh}} {
+		corners[i] = image.Pt(
+			int(math.Round(b.CX+d[0]*cos-d[1]*sin)),
+			int(math.Round(b.CY+d[0]*sin+d[1]*cos)),
+		)
+	}
+	return corners
+}
+
+// สำหรับแปลงเป็น Polygon 4 มุม
+func (b OrientedBox) Polygon() Polygon {
+	return Polygon{Points: b.Corners()}
+}
+
+// กรอบสี่เหลี่ยมแนวแกนที่เล็กที่สุดที่ครอบมุมทั้ง 4 (Max คือ pixel สุดท้ายเหมือน PlotDataModel.Rect)
+func (b OrientedBox) Bounds() image.Rectangle {
+	return pointsBounds(b.Corners())
+}
+
+// ถ้า ScaleX กับ ScaleY ไม่เท่ากัน กรอบที่หมุนอยู่จะเป็นค่าประมาณ (ขนาดถูก scale ตามแกนของภาพ)
+func (b OrientedBox) Transform(t Transform) Shape {
+	b.CX, b.CY = t.Apply(b.CX, b.CY)
+	b.Width *= math.Abs(t.ScaleX)
+	b.Height *= math.Abs(t.ScaleY)
+	return b
+}
+
+func (b OrientedBox) Draw(dst draw.Image, style PlotStyle) {
+	b.Polygon().Draw(dst, style)
+}
@@ -0,0 +1,100 @@
+package mimage_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrientedBoxCorners(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Box      mimage.OrientedBox
+		Expected []image.Point
+		Bounds   image.Rectangle
+	}{
+		{
+			Name:     "No rotation",
+			Box:      mimage.OrientedBox{CX: 50, CY: 40, Width: 40, Height: 20},
+			Expected: []image.Point{{30, 30}, {70, 30}, {70, 50}, {30, 50}},
+			Bounds:   image.Rect(30, 30, 70, 50),
+		},
+		{
+			Name:     "Rotated 90 degrees clockwise",
+			Box:      mimage.OrientedBox{CX: 50, CY: 40, Width: 40, Height: 20, Angle: 90},
+			Expected: []image.Point{{60, 20}, {60, 60}, {40, 60}, {40, 20}},
+			Bounds:   image.Rect(40, 20, 60, 60),
+		},
+		{
+			Name:     "Rotated 45 degrees",
+			Box:      mimage.OrientedBox{CX: 100, CY: 100, Width: 20, Height: 20, Angle: 45},
+			Expected: []image.Point{{100, 86}, {114, 100}, {100, 114}, {86, 100}},
+			Bounds:   image.Rect(86, 86, 114, 114),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			corners := tt.Box.Corners()
+
+			// --------------- Assert ---------------
+			assert.Equal(t, tt.Expected, corners)
+			assert.Equal(t, tt.Expected, tt.Box.Polygon().Points)
+			assert.Equal(t, tt.Bounds, tt.Box.Bounds())
+		})
+	}
+}
+
+func TestOrientedBoxFromCorners(t *testing.T) {
+	// --------------- Arrange ---------------
+	box := mimage.OrientedBox{CX: 80, CY: 60, Width: 60, Height: 30, Angle: 30}
+
+	// --------------- Act ---------------
+	result, err := mimage.OrientedBoxFromCorners(box.Corners())
+	_, invalidErr := mimage.OrientedBoxFromCorners([]image.Point{{0, 0}, {1, 1}, {2, 2}})
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.InDelta(t, box.CX, result.CX, 0.5)
+	assert.InDelta(t, box.CY, result.CY, 0.5)
+	assert.InDelta(t, box.Width, result.Width, 1)
+	assert.InDelta(t, box.Height, result.Height, 1)
+	assert.InDelta(t, box.Angle, result.Angle, 1)
+	assert.ErrorIs(t, invalidErr, mimage.ErrCornerCount)
+}
+
+func TestOrientedBoxFromRect(t *testing.T) {
+	// --------------- Act ---------------
+	result := mimage.OrientedBoxFromRect(image.Rect(60, 50, 20, 10))
+
+	// --------------- Assert ---------------
+	assert.Equal(t, mimage.OrientedBox{CX: 40, CY: 30, Width: 40, Height: 40}, result)
+	assert.Equal(t, image.Rect(20, 10, 60, 50), result.Bounds())
+}
+
+func TestPlotImageWithOrientedBox(t *testing.T) {
+	// --------------- Act ---------------
+	result, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{
+		{
+			Shape: mimage.OrientedBox{CX: 100, CY: 100, Width: 100, Height: 40, Angle: 90},
+			Label: "plate",
+			Style: mimage.PlotStyle{Thickness: 2, FontSize: 12, FillColor: color.RGBA{0, 0, 255, 255}},
+		},
+	})
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	img, _, err := image.Decode(bytes.NewReader(result))
+	assert.NoError(t, err)
+	red := color.RGBA{255, 0, 0, 255}
+	assert.Equal(t, red, color.RGBAModel.Convert(img.At(80, 100)))
+	assert.Equal(t, red, color.RGBAModel.Convert(img.At(120, 100)))
+	assert.Equal(t, color.RGBA{0, 0, 255, 255}, color.RGBAModel.Convert(img.At(100, 100)))
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, color.RGBAModel.Convert(img.At(60, 100)))
+	// label อยู่เหนือขอบเขตของกรอบที่หมุนแล้ว
+	assert.Equal(t, red, color.RGBAModel.Convert(img.At(81, 48)))
+}
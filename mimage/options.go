@@ -0,0 +1,113 @@
+package mimage
+
+import (
+	"fmt"
+	"image/png"
+)
+
+// สำหรับกำหนด option ให้กับ PlotImage*
+type Option func(*options)
+
+type options struct {
+	format         string
+	jpegQuality    int
+	pngCompression png.CompressionLevel
+	fonts          *FontRegistry
+
+	avoidLabelOverlap bool
+
+	labelFormatter LabelFormatter
+	minScore       float64
+	classes        []string
+
+	palette Palette
+	legend  LegendPosition
+
+	strict bool
+
+	maxDimension  int
+	interpolation Interpolation
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{fonts: DefaultFonts, labelFormatter: DefaultLabelFormatter}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *options) validate() error {
+	if o.format == "" {
+		return nil
+	}
+	switch o.format {
+	case "jpeg", "png", "bmp", "tiff":
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrUnsupportedFormat, o.format)
+}
+
+// สำหรับกำหนด font ที่ใช้วาด label (default: DefaultFonts)
+func WithFonts(fonts *FontRegistry) Option {
+	return func(o *options) {
+		if fonts != nil {
+			o.fonts = fonts
+		}
+	}
+}
+
+// สำหรับจัดตำแหน่ง label ไม่ให้ทับกันเมื่อมีกรอบจำนวนมาก
+// label ที่ถูกเลื่อนออกจากตำแหน่งเดิมจะมีเส้นโยงกลับไปที่กรอบ ผลลัพธ์เหมือนเดิมทุกครั้งสำหรับ input เดียวกัน
+func WithAvoidLabelOverlap() Option {
+	return func(o *options) {
+		o.avoidLabelOverlap = true
+	}
+}
+
+// สำหรับกำหนดวิธีสร้างข้อความ label จากแต่ละกรอบ (default: DefaultLabelFormatter)
+func WithLabelFormatter(f LabelFormatter) Option {
+	return func(o *options) {
+		if f != nil {
+			o.labelFormatter = f
+		}
+	}
+}
+
+// สำหรับตัดกรอบที่มี Score น้อยกว่า min ออกก่อนวาด (กรอบที่ไม่มี Score จะถูกตัดด้วย)
+func WithMinScore(min float64) Option {
+	return func(o *options) {
+		o.minScore = min
+	}
+}
+
+// สำหรับวาดเฉพาะกรอบที่มี Class อยู่ใน classes
+func WithClasses(classes ...string) Option {
+	return func(o *options) {
+		o.classes = classes
+	}
+}
+
+// สำหรับกำหนดชุดสีที่ใช้กับกรอบที่มี Class แต่ไม่ได้กำหนด Style.Color (default: ClassColor)
+func WithPalette(p Palette) Option {
+	return func(o *options) {
+		if len(p) > 0 {
+			o.palette = p
+		}
+	}
+}
+
+// สำหรับวาด legend แสดง class, สี และจำนวนกรอบ ที่มุมของภาพหรือเป็นแถบต่อท้ายภาพ
+func WithLegend(pos LegendPosition) Option {
+	return func(o *options) {
+		o.legend = pos
+	}
+}
+
+// สำหรับคืนค่า *ValidationError เมื่อมีกรอบที่กลับด้าน ไม่มีพื้นที่ หรือล้นออกนอกภาพ
+// แทนการปรับกรอบให้อยู่ในภาพแล้ววาดต่อ
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
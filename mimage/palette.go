@@ -0,0 +1,93 @@
+package mimage
+
+import (
+	"hash/fnv"
+	"image/color"
+	"math"
+)
+
+// ชุดสีสำหรับแยก class
+type Palette []color.Color
+
+// ชุดสี 20 สีที่แยกกันได้ชัด (Kelly's colors of maximum contrast ไม่รวมขาวและดำ) ใช้กับ WithPalette ได้
+// เหมาะกับภาพที่มี class ไม่กี่ class เพราะ class ต่างกันอาจได้สีเดียวกัน
+var DefaultPalette = Palette{
+	color.RGBA{0xF3, 0xC3, 0x00, 0xff}, // vivid yellow
+	color.RGBA{0x87, 0x56, 0x92, 0xff}, // strong purple
+	color.RGBA{0xF3, 0x84, 0x00, 0xff}, // vivid orange
+	color.RGBA{0xA1, 0xCA, 0xF1, 0xff}, // very light blue
+	color.RGBA{0xBE, 0x00, 0x32, 0xff}, // vivid red
+	color.RGBA{0xC2, 0xB2, 0x80, 0xff}, // grayish yellow
+	color.RGBA{0x84, 0x84, 0x82, 0xff}, // medium gray
+	color.RGBA{0x00, 0x88, 0x56, 0xff}, // vivid green
+	color.RGBA{0xE6, 0x8F, 0xAC, 0xff}, // strong purplish pink
+	color.RGBA{0x00, 0x67, 0xA5, 0xff}, // strong blue
+	color.RGBA{0xF9, 0x93, 0x79, 0xff}, // strong yellowish pink
+	color.RGBA{0x60, 0x4E, 0x97, 0xff}, // strong violet
+	color.RGBA{0xF6, 0xA6, 0x00, 0xff}, // vivid orange yellow
+	color.RGBA{0xB3, 0x44, 0x6C, 0xff}, // strong purplish red
+	color.RGBA{0xDC, 0xD3, 0x00, 0xff}, // vivid greenish yellow
+	color.RGBA{0x88, 0x2D, 0x17, 0xff}, // strong reddish brown
+	color.RGBA{0x8D, 0xB6, 0x00, 0xff}, // vivid yellowish green
+	color.RGBA{0x65, 0x45, 0x22, 0xff}, // deep yellowish brown
+	color.RGBA{0xE2, 0x58, 0x22, 0xff}, // vivid reddish orange
+	color.RGBA{0x2B, 0x3D, 0x26, 0xff}, // dark olive green
+}
+
+// index ของสีสำหรับ class โดยใช้ hash ของชื่อ จึงได้สีเดิมทุกครั้งไม่ว่าจะอยู่ในภาพไหน
+func (p Palette) index(class string) int {
+	h := fnv.New32a()
+	h.Write([]byte(class))
+	return int(h.Sum32() % uint32(len(p)))
+}
+
+// สีของ class ตาม hash ของชื่อ ถ้า p ว่างจะใช้ ClassColor
+// ชุดสีมีจำนวนจำกัด class ต่างกันจึงอาจได้สีเดียวกัน (เช่น "face" กับ "person" ใน DefaultPalette)
+func (p Palette) ColorOf(class string) color.Color {
+	if len(p) == 0 {
+		return ClassColor(class)
+	}
+	return p[p.index(class)]
+}
+
+// สีของ class ที่สร้างจาก hash ของชื่อ โดยใช้ hue ได้ทั้งวงล้อสี ส่วนความอิ่มตัวและความสว่างอยู่ในช่วงที่เห็นชัดบนภาพ
+// ได้สีเดิมทุกครั้งไม่ว่าจะมี class อื่นอยู่ในภาพหรือไม่ และไม่ซ้ำกันระหว่าง class ที่ชื่อต่างกัน (เช่น class ทั้ง 80 ของ COCO)
+func ClassColor(class string) color.Color {
+	h := fnv.New64a()
+	h.Write([]byte(class))
+	v := h.Sum64()
+	hue := float64(v>>32) / (1 << 32) * 6
+	sat := 0.6 + float64(v>>16&0xffff)/0xffff*0.35
+	val := 0.75 + float64(v&0xffff)/0xffff*0.2
+
+	// แปลง HSV เป็น RGB โดย hue อยู่ในช่วง 0-6 (ช่วงละ 60 องศา)
+	c := val * sat
+	x := c * (1 - math.Abs(math.Mod(hue, 2)-1))
+	var r, g, b float64
+	switch int(hue) {
+	case 0:
+		r, g, b = c, x, 0
+	case 1:
+		r, g, b = x, c, 0
+	case 2:
+		r, g, b = 0, c, x
+	case 3:
+		r, g, b = 0, x, c
+	case 4:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	m := val - c
+	return color.RGBA{uint8(math.Round((r + m) * 255)), uint8(math.Round((g + m) * 255)), uint8(math.Round((b + m) * 255)), 0xff}
+}
+
+// กำหนดสีให้กรอบที่มี Class แต่ไม่ได้กำหนด Style.Color ด้วย ColorOf ของชุดสีที่เลือก
+// สีขึ้นกับชื่อ class เท่านั้น จึงไม่เปลี่ยนตาม class อื่นที่อยู่ในภาพเดียวกัน
+func (o *options) applyPalette(plots []PlotDataModel) {
+	for i := range plots {
+		if plots[i].Class != "" && plots[i].Style.Color == nil {
+			plots[i].Style.Color = o.palette.ColorOf(plots[i].Class)
+		}
+	}
+}
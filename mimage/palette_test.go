@@ -0,0 +1,168 @@
+package mimage_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaletteColorOf(t *testing.T) {
+	// --------------- Act ---------------
+	first := mimage.DefaultPalette.ColorOf("face")
+	second := mimage.DefaultPalette.ColorOf("face")
+	single := mimage.Palette{color.Black}.ColorOf("person")
+
+	// --------------- Assert ---------------
+	assert.Equal(t, first, second)
+	assert.Contains(t, mimage.DefaultPalette, first)
+	assert.Equal(t, color.Black, single)
+}
+
+func TestPlotImageWithPalette(t *testing.T) {
+	plotData := []mimage.PlotDataModel{
+		{Rect: image.Rect(10, 10, 40, 40), Class: "face"},
+		{Rect: image.Rect(60, 10, 90, 40), Class: "person"},
+		{Rect: image.Rect(110, 10, 140, 40), Class: "face"},
+		{Rect: image.Rect(10, 60, 40, 90), Class: "face", Style: mimage.PlotStyle{Color: color.RGBA{0, 0, 0, 255}}},
+		{Rect: image.Rect(60, 60, 90, 90)},
+	}
+	tests := []struct {
+		Name    string
+		Options []mimage.Option
+		Palette mimage.Palette
+	}{
+		{Name: "Default colors"},
+		{Name: "Kelly palette", Options: []mimage.Option{mimage.WithPalette(mimage.DefaultPalette)}, Palette: mimage.DefaultPalette},
+		{
+			Name:    "Custom palette",
+			Options: []mimage.Option{mimage.WithPalette(mimage.Palette{color.RGBA{0, 255, 0, 255}, color.RGBA{0, 0, 255, 255}})},
+			Palette: mimage.Palette{color.RGBA{0, 255, 0, 255}, color.RGBA{0, 0, 255, 255}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result, err := mimage.PlotImageFromBytes(createTestImage("png"), plotData, tt.Options...)
+
+			// --------------- Assert ---------------
+			assert.NoError(t, err)
+			img, _, _ := image.Decode(bytes.NewReader(result))
+			at := func(p mimage.PlotDataModel) color.Color {
+				return color.RGBAModel.Convert(img.At(p.Rect.Min.X+5, p.Rect.Max.Y))
+			}
+			assert.Equal(t, tt.Palette.ColorOf("face"), at(plotData[0]))
+			assert.Equal(t, tt.Palette.ColorOf("person"), at(plotData[1]))
+			assert.Equal(t, at(plotData[0]), at(plotData[2]))
+			assert.Equal(t, color.RGBA{0, 0, 0, 255}, at(plotData[3]))
+			assert.Equal(t, color.RGBA{255, 0, 0, 255}, at(plotData[4]))
+		})
+	}
+}
+
+func TestClassColor(t *testing.T) {
+	// --------------- Arrange ---------------
+	coco := []string{
+		"person", "bicycle", "car", "motorcycle", "airplane", "bus", "train", "truck", "boat", "traffic light",
+		"fire hydrant", "stop sign", "parking meter", "bench", "bird", "cat", "dog", "horse", "sheep", "cow",
+		"elephant", "bear", "zebra", "giraffe", "backpack", "umbrella", "handbag", "tie", "suitcase", "frisbee",
+		"skis", "snowboard", "sports ball", "kite", "baseball bat", "baseball glove", "skateboard", "surfboard", "tennis racket", "bottle",
+		"wine glass", "cup", "fork", "knife", "spoon", "bowl", "banana", "apple", "sandwich", "orange",
+		"broccoli", "carrot", "hot dog", "pizza", "donut", "cake", "chair", "couch", "potted plant", "bed",
+		"dining table", "toilet", "tv", "laptop", "mouse", "remote", "keyboard", "cell phone", "microwave", "oven",
+		"toaster", "sink", "refrigerator", "book", "clock", "vase", "scissors", "teddy bear", "hair drier", "toothbrush",
+	}
+
+	// --------------- Act ---------------
+	colors := make(map[color.Color][]string)
+	for _, class := range append(coco, "face") {
+		c := mimage.ClassColor(class)
+		colors[c] = append(colors[c], class)
+	}
+
+	// --------------- Assert ---------------
+	for _, classes := range colors {
+		assert.Len(t, classes, 1, "%q have the same color", classes)
+	}
+	assert.NotEqual(t, mimage.ClassColor("car"), mimage.ClassColor("train"))
+	assert.Equal(t, mimage.ClassColor("face"), mimage.ClassColor("face"))
+	assert.Equal(t, mimage.ClassColor("face"), mimage.Palette(nil).ColorOf("face"))
+}
+
+func TestPlotImageWithDefaultPaletteIsStable(t *testing.T) {
+	// --------------- Arrange ---------------
+	face := mimage.PlotDataModel{Rect: image.Rect(10, 10, 40, 40), Class: "face"}
+	person := mimage.PlotDataModel{Rect: image.Rect(60, 10, 90, 40), Class: "person"}
+	colorAt := func(result []byte, p mimage.PlotDataModel) color.Color {
+		img, _, _ := image.Decode(bytes.NewReader(result))
+		return color.RGBAModel.Convert(img.At(p.Rect.Min.X+5, p.Rect.Max.Y))
+	}
+
+	// --------------- Act ---------------
+	alone, err1 := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{face})
+	together, err2 := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{face, person})
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, mimage.ClassColor("face"), colorAt(alone, face))
+	assert.Equal(t, colorAt(alone, face), colorAt(together, face))
+	assert.NotEqual(t, colorAt(together, face), colorAt(together, person))
+}
+
+func TestPlotImageWithLegend(t *testing.T) {
+	plotData := []mimage.PlotDataModel{
+		{Rect: image.Rect(60, 60, 90, 90), Class: "face"},
+		{Rect: image.Rect(100, 60, 130, 90), Class: "face"},
+		{Rect: image.Rect(60, 100, 90, 130), Class: "person"},
+	}
+	tests := []struct {
+		Name           string
+		Position       mimage.LegendPosition
+		ExpectedBounds image.Rectangle
+		Corner         image.Point
+	}{
+		{Name: "Top left", Position: mimage.LegendTopLeft, ExpectedBounds: image.Rect(0, 0, 200, 200), Corner: image.Pt(6, 6)},
+		{Name: "Top right", Position: mimage.LegendTopRight, ExpectedBounds: image.Rect(0, 0, 200, 200), Corner: image.Pt(193, 6)},
+		{Name: "Bottom left", Position: mimage.LegendBottomLeft, ExpectedBounds: image.Rect(0, 0, 200, 200), Corner: image.Pt(6, 193)},
+		{Name: "Bottom right", Position: mimage.LegendBottomRight, ExpectedBounds: image.Rect(0, 0, 200, 200), Corner: image.Pt(193, 193)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result, err := mimage.PlotImageFromBytes(createTestImage("png"), plotData, mimage.WithLegend(tt.Position))
+
+			// --------------- Assert ---------------
+			assert.NoError(t, err)
+			img, _, _ := image.Decode(bytes.NewReader(result))
+			assert.Equal(t, tt.ExpectedBounds, img.Bounds())
+			assert.NotEqual(t, color.RGBA{255, 255, 255, 255}, color.RGBAModel.Convert(img.At(tt.Corner.X, tt.Corner.Y)))
+		})
+	}
+
+	t.Run("Strip", func(t *testing.T) {
+		// --------------- Act ---------------
+		result, err := mimage.PlotImageFromBytes(createTestImage("png"), plotData, mimage.WithLegend(mimage.LegendStrip))
+
+		// --------------- Assert ---------------
+		assert.NoError(t, err)
+		img, _, _ := image.Decode(bytes.NewReader(result))
+		assert.Equal(t, 200, img.Bounds().Dx())
+		assert.Greater(t, img.Bounds().Dy(), 200)
+		assert.Equal(t, color.RGBA{255, 255, 255, 255}, color.RGBAModel.Convert(img.At(5, 195)))
+		assert.Equal(t, color.RGBA{32, 32, 32, 255}, color.RGBAModel.Convert(img.At(199, img.Bounds().Max.Y-1)))
+	})
+
+	t.Run("No classes", func(t *testing.T) {
+		// --------------- Act ---------------
+		result, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{{Rect: image.Rect(60, 60, 90, 90)}}, mimage.WithLegend(mimage.LegendStrip))
+
+		// --------------- Assert ---------------
+		assert.NoError(t, err)
+		img, _, _ := image.Decode(bytes.NewReader(result))
+		assert.Equal(t, image.Rect(0, 0, 200, 200), img.Bounds())
+	})
+}
@@ -0,0 +1,109 @@
+package mimage
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/draw"
+
+	"github.com/inetmanageai/utils/mslices"
+)
+
+// สำหรับวาดกรอบและ label ลงบนภาพจาก src แล้ว encode กลับเป็น []byte
+// คืนค่า format ของภาพผลลัพธ์มาด้วย (ดู OutputFormat และ WithFormat)
+func PlotImage(ctx context.Context, src Source, plotData []PlotDataModel, opts ...Option) (result []byte, format string, err error) {
+	o := newOptions(opts)
+	if err := o.validate(); err != nil {
+		return nil, "", err
+	}
+
+	// read file and convert it
+	img, t, err := LoadImage(ctx, src)
+	if err != nil {
+		return nil, "", err
+	}
+	img, plotData = o.downscale(img, plotData)
+
+	img, err = render(img, plotData, o)
+	if err != nil {
+		return nil, "", err
+	}
+
+	buf := new(bytes.Buffer)
+	format, err = encodeImage(buf, img, t, o)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), format, nil
+}
+
+// วาดทุกอย่างลงบน img ตาม option ภาพที่คืนค่าอาจเป็นภาพใหม่ถ้าขนาดเปลี่ยน (เช่น legend แบบ strip)
+func render(img *image.RGBA, plotData []PlotDataModel, o *options) (*image.RGBA, error) {
+	plotData = resolveBoxes(img.Bounds(), plotData)
+	if o.strict {
+		if err := validatePlots(img.Bounds(), plotData); err != nil {
+			return nil, err
+		}
+	}
+
+	plotData = mslices.Filter(plotData, o.keep)
+	o.applyPalette(plotData)
+	drawMasks(img, plotData)
+
+	// ตัดกรอบที่อยู่นอกภาพทั้งหมดออก ที่เหลือตัดให้อยู่ในภาพ
+	visible := make([]PlotDataModel, 0, len(plotData))
+	for _, p := range plotData {
+		clip := clipRect
+		if p.Shape != nil {
+			clip = clipShapeBounds
+		}
+		if r, ok := clip(p.Rect, img.Bounds()); ok {
+			p.Rect = r
+			visible = append(visible, p)
+		}
+	}
+	plotData = visible
+
+	labels := make([]labelTag, 0, len(plotData))
+	for _, p := range plotData {
+		if tag := addRectangleToFace(img, p, o.labelFormatter(p)); tag.text != "" {
+			labels = append(labels, tag)
+		}
+	}
+
+	// draw label
+	if err := drawLabels(img, o, labels); err != nil {
+		return nil, err
+	}
+
+	if o.legend != LegendNone {
+		return drawLegend(img, o, legendItems(plotData))
+	}
+	return img, nil
+}
+
+// สำหรับอ่านภาพจาก src แล้วแปลงเป็น *image.RGBA ที่พร้อมแก้ไข พร้อม format ของภาพต้นฉบับ
+func LoadImage(ctx context.Context, src Source) (*image.RGBA, string, error) {
+	rc, err := src.Open(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rc.Close()
+
+	// convert as image.Image
+	orig, typeImage, err := image.Decode(rc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return toRGBA(orig), typeImage, nil
+}
+
+func toRGBA(orig image.Image) *image.RGBA {
+	// สร้าง instance image สำหรับแก้ไขไฟล์ภาพ
+	b := orig.Bounds()                                     // ดึงขนาด orig img
+	img := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy())) // กำหนด instance ขนาดเท่ากับ orig img
+	draw.Draw(img, img.Bounds(), orig, b.Min, draw.Src)    // copy orig image ใส่ใน instance ขนาดตามที่กำหนด
+	return img
+}
@@ -0,0 +1,202 @@
+package mimage
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/inetmanageai/utils/mslices"
+)
+
+// วิธีปิดบังพื้นที่ใน Redact
+type RedactMode int
+
+const (
+	RedactBlur     RedactMode = iota // pixelate แล้ว Gaussian blur ให้ดูนุ่มแต่กู้ภาพเดิมไม่ได้
+	RedactPixelate                   // เฉลี่ยสีเป็นบล็อกสี่เหลี่ยมขนาด BlockSize
+	RedactFill                       // ระบายด้วยสีทึบ Color
+)
+
+// กำหนดวิธีปิดบังของ Redact field ไหนที่เป็น zero value จะใช้ค่า default
+type Redaction struct {
+	Mode      RedactMode
+	BlockSize int         // ขนาดบล็อกของ pixelate เป็น pixel อย่างน้อย 2 (default: 1/10 ของด้านที่สั้นกว่า อย่างน้อย 4)
+	Sigma     float64     // ความเบลอของ RedactBlur (default: เท่ากับ BlockSize)
+	Color     color.Color // สีของ RedactFill (default: ดำ)
+	Ellipse   bool        // ปิดบังเฉพาะวงรีที่อยู่ในกรอบ เหมาะกับใบหน้า
+	Padding   float64     // ขยายกรอบออกทุกด้านเป็นสัดส่วนของขนาดกรอบ เช่น 0.1 คือ 10%
+}
+
+// สำหรับปิดบังพื้นที่ของทุกกรอบใน plotData (เช่นใบหน้า) ก่อนส่งภาพออกนอกระบบ แล้ว encode กลับเป็น []byte
+// ทุกโหมดแทนที่ pixel ในพื้นที่ด้วยค่าใหม่ทั้งหมด จึงไม่เหลือ pixel เดิมให้กู้คืนได้
+// กรอบที่มีแต่ Mask จะปิดบังขอบเขตของ mask และกรอบที่ไม่มีพื้นที่เลยจะถูกข้าม
+// ใช้ option เดียวกับ PlotImage สำหรับ filter กรอบ (WithMinScore, WithClasses), WithStrict และการ encode
+func Redact(ctx context.Context, src Source, plotData []PlotDataModel, r Redaction, opts ...Option) (result []byte, format string, err error) {
+	o := newOptions(opts)
+	if err := o.validate(); err != nil {
+		return nil, "", err
+	}
+
+	img, t, err := LoadImage(ctx, src)
+	if err != nil {
+		return nil, "", err
+	}
+
+	plotData = resolveBoxes(img.Bounds(), plotData)
+	if o.strict {
+		if err := validatePlots(img.Bounds(), plotData); err != nil {
+			return nil, "", err
+		}
+	}
+	for _, p := range mslices.Filter(plotData, o.keep) {
+		if area, ok := plotArea(p, img.Bounds()); ok {
+			redactRect(img, area, r)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	format, err = encodeImage(buf, img, t, o)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), format, nil
+}
+
+func redactRect(img *image.RGBA, rect image.Rectangle, r Redaction) {
+	if r.Padding > 0 {
+		pad := image.Pt(int(math.Round(float64(rect.Dx())*r.Padding)), int(math.Round(float64(rect.Dy())*r.Padding)))
+		rect = image.Rectangle{Min: rect.Min.Sub(pad), Max: rect.Max.Add(pad)}
+	}
+	area := rect.Intersect(img.Bounds())
+	if area.Empty() {
+		return
+	}
+
+	// ทำบนสำเนาของพื้นที่ แล้วค่อย copy กลับเฉพาะ pixel ที่อยู่ในวงรี (ถ้ากำหนด)
+	tmp := image.NewRGBA(area)
+	switch r.Mode {
+	case RedactFill:
+		c := r.Color
+		if c == nil {
+			c = color.Black
+		}
+		draw.Draw(tmp, area, image.NewUniform(c), image.Point{}, draw.Src)
+	default:
+		draw.Draw(tmp, area, img, area.Min, draw.Src)
+		block := r.BlockSize
+		if block <= 0 {
+			block = max(min(rect.Dx(), rect.Dy())/10, 4)
+		}
+		pixelate(tmp, block)
+		if r.Mode == RedactBlur {
+			sigma := r.Sigma
+			if sigma <= 0 {
+				sigma = float64(block)
+			}
+			gaussianBlur(tmp, sigma)
+		}
+	}
+
+	if !r.Ellipse {
+		draw.Draw(img, area, tmp, area.Min, draw.Src)
+		return
+	}
+
+	// วงรีที่แนบในกรอบก่อนตัดให้อยู่ในภาพ
+	cx, cy := float64(rect.Min.X+rect.Max.X)/2, float64(rect.Min.Y+rect.Max.Y)/2
+	rx, ry := float64(rect.Dx())/2, float64(rect.Dy())/2
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		dy := (float64(y) + 0.5 - cy) / ry
+		if dy*dy > 1 {
+			continue
+		}
+		half := rx * math.Sqrt(1-dy*dy)
+		x0 := max(int(math.Ceil(cx-half-0.5)), area.Min.X)
+		x1 := min(int(math.Floor(cx+half-0.5))+1, area.Max.X)
+		if x0 < x1 {
+			i, j := img.PixOffset(x0, y), tmp.PixOffset(x0, y)
+			copy(img.Pix[i:i+(x1-x0)*4], tmp.Pix[j:j+(x1-x0)*4])
+		}
+	}
+}
+
+// แทนที่ทุกบล็อกขนาดประมาณ size x size ด้วยสีเฉลี่ยของบล็อก
+// แบ่งบล็อกให้ขนาดใกล้เคียงกันทั้งภาพ เพื่อไม่ให้เหลือบล็อกเล็ก ๆ ที่ขอบซึ่งยังเห็นค่าเดิม
+func pixelate(img *image.RGBA, size int) {
+	b := img.Bounds()
+	size = max(size, 2)
+	nx, ny := max(int(math.Round(float64(b.Dx())/float64(size))), 1), max(int(math.Round(float64(b.Dy())/float64(size))), 1)
+	for j := 0; j < ny; j++ {
+		for i := 0; i < nx; i++ {
+			block := image.Rect(
+				b.Min.X+i*b.Dx()/nx, b.Min.Y+j*b.Dy()/ny,
+				b.Min.X+(i+1)*b.Dx()/nx, b.Min.Y+(j+1)*b.Dy()/ny,
+			)
+
+			var sum [4]int
+			for y := block.Min.Y; y < block.Max.Y; y++ {
+				row := img.Pix[img.PixOffset(block.Min.X, y):img.PixOffset(block.Max.X, y)]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i+0])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+
+			n := block.Dx() * block.Dy()
+			avg := color.RGBA{uint8(sum[0] / n), uint8(sum[1] / n), uint8(sum[2] / n), uint8(sum[3] / n)}
+			draw.Draw(img, block, image.NewUniform(avg), image.Point{}, draw.Src)
+		}
+	}
+}
+
+// Gaussian blur โดยประมาณด้วย box blur 3 รอบในแนวนอนและแนวตั้ง ใช้เวลาคงที่ต่อ pixel ไม่ว่า sigma จะเท่าไร
+// ขอบของภาพใช้ค่าของ pixel ที่ขอบซ้ำ
+func gaussianBlur(img *image.RGBA, sigma float64) {
+	const passes = 3
+	radius := int(math.Sqrt(12*sigma*sigma/passes+1)) / 2
+	if radius < 1 {
+		return
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	line := make([]uint8, max(w, h)*4)
+	for i := 0; i < passes; i++ {
+		for y := 0; y < h; y++ {
+			start := img.PixOffset(b.Min.X, b.Min.Y+y)
+			boxBlurLine(img.Pix[start:], 4, w, radius, line)
+		}
+		for x := 0; x < w; x++ {
+			start := img.PixOffset(b.Min.X+x, b.Min.Y)
+			boxBlurLine(img.Pix[start:], img.Stride, h, radius, line)
+		}
+	}
+}
+
+// box blur ของ n pixel ที่ห่างกัน step byte ใน pix โดยใช้ผลรวมสะสม ผลลัพธ์เขียนกลับลง pix
+func boxBlurLine(pix []uint8, step, n, radius int, line []uint8) {
+	for i := 0; i < n; i++ {
+		copy(line[i*4:i*4+4], pix[i*step:i*step+4])
+	}
+
+	width := 2*radius + 1
+	for c := 0; c < 4; c++ {
+		at := func(i int) int {
+			return int(line[min(max(i, 0), n-1)*4+c])
+		}
+		sum := 0
+		for i := -radius; i <= radius; i++ {
+			sum += at(i)
+		}
+		for i := 0; i < n; i++ {
+			pix[i*step+c] = uint8((sum + width/2) / width)
+			sum += at(i+radius+1) - at(i-radius)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package mimage_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+// ภาพ 200x200 ลายตารางหมากรุกขาวดำช่องละ 1 pixel
+func createCheckerImage() (*image.RGBA, []byte) {
+	img := image.NewRGBA(image.Rect(0, 0, 200, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 200; x++ {
+			if (x+y)%2 == 0 {
+				img.Set(x, y, color.White)
+			} else {
+				img.Set(x, y, color.Black)
+			}
+		}
+	}
+	buf := new(bytes.Buffer)
+	png.Encode(buf, img)
+	return img, buf.Bytes()
+}
+
+func TestRedact(t *testing.T) {
+	orig, data := createCheckerImage()
+	gray := color.RGBA{127, 127, 127, 255}
+	tests := []struct {
+		Name      string
+		Redaction mimage.Redaction
+		Changed   []image.Point
+		Unchanged []image.Point
+		Expected  map[image.Point]color.RGBA
+	}{
+		{
+			Name:      "Fill with default black",
+			Redaction: mimage.Redaction{Mode: mimage.RedactFill},
+			Unchanged: []image.Point{{49, 100}, {151, 100}},
+			Expected:  map[image.Point]color.RGBA{{50, 50}: {0, 0, 0, 255}, {150, 150}: {0, 0, 0, 255}, {100, 100}: {0, 0, 0, 255}},
+		},
+		{
+			Name:      "Fill with color",
+			Redaction: mimage.Redaction{Mode: mimage.RedactFill, Color: color.RGBA{0, 0, 255, 255}},
+			Expected:  map[image.Point]color.RGBA{{100, 100}: {0, 0, 255, 255}},
+		},
+		{
+			Name:      "Pixelate averages blocks",
+			Redaction: mimage.Redaction{Mode: mimage.RedactPixelate, BlockSize: 10},
+			Unchanged: []image.Point{{49, 49}, {151, 151}},
+			Expected:  map[image.Point]color.RGBA{{50, 50}: gray, {59, 59}: gray, {101, 100}: gray},
+		},
+		{
+			Name:      "Blur",
+			Redaction: mimage.Redaction{Mode: mimage.RedactBlur},
+			Unchanged: []image.Point{{49, 100}, {152, 100}},
+			Expected:  map[image.Point]color.RGBA{{100, 100}: gray, {101, 100}: gray, {50, 50}: gray},
+		},
+		{
+			Name:      "Ellipse keeps the corners",
+			Redaction: mimage.Redaction{Mode: mimage.RedactFill, Ellipse: true},
+			Unchanged: []image.Point{{50, 50}, {150, 150}, {52, 148}},
+			Expected:  map[image.Point]color.RGBA{{100, 100}: {0, 0, 0, 255}, {50, 100}: {0, 0, 0, 255}, {100, 50}: {0, 0, 0, 255}},
+		},
+		{
+			Name:      "Padding grows the region",
+			Redaction: mimage.Redaction{Mode: mimage.RedactFill, Padding: 0.1},
+			Unchanged: []image.Point{{39, 100}, {161, 100}},
+			Expected:  map[image.Point]color.RGBA{{40, 100}: {0, 0, 0, 255}, {160, 100}: {0, 0, 0, 255}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result, format, err := mimage.Redact(context.Background(), mimage.BytesSource{Data: data}, []mimage.PlotDataModel{
+				{Rect: image.Rect(50, 50, 150, 150)},
+			}, tt.Redaction)
+
+			// --------------- Assert ---------------
+			assert.NoError(t, err)
+			assert.Equal(t, "png", format)
+			img, _, err := image.Decode(bytes.NewReader(result))
+			assert.NoError(t, err)
+			for _, p := range tt.Unchanged {
+				assert.Equal(t, orig.At(p.X, p.Y), color.RGBAModel.Convert(img.At(p.X, p.Y)), "pixel %v", p)
+			}
+			for p, expected := range tt.Expected {
+				c := color.RGBAModel.Convert(img.At(p.X, p.Y)).(color.RGBA)
+				assert.InDelta(t, expected.R, c.R, 2, "pixel %v", p)
+				assert.InDelta(t, expected.G, c.G, 2, "pixel %v", p)
+				assert.InDelta(t, expected.B, c.B, 2, "pixel %v", p)
+			}
+		})
+	}
+}
+
+func TestRedactNoOriginalPixels(t *testing.T) {
+	_, data := createCheckerImage()
+	for _, mode := range []mimage.RedactMode{mimage.RedactBlur, mimage.RedactPixelate} {
+		// --------------- Act ---------------
+		result, _, err := mimage.Redact(context.Background(), mimage.BytesSource{Data: data}, []mimage.PlotDataModel{
+			{Rect: image.Rect(20, 20, 120, 120)},
+		}, mimage.Redaction{Mode: mode})
+
+		// --------------- Assert ---------------
+		assert.NoError(t, err)
+		img, _, err := image.Decode(bytes.NewReader(result))
+		assert.NoError(t, err)
+		for y := 20; y <= 120; y++ {
+			for x := 20; x <= 120; x++ {
+				r, _, _, _ := img.At(x, y).RGBA()
+				if r == 0 || r == 0xffff {
+					t.Fatalf("mode %d: pixel %d,%d still has its original value", mode, x, y)
+				}
+			}
+		}
+	}
+}
+
+func TestRedactWithFilterAndStrict(t *testing.T) {
+	_, data := createCheckerImage()
+
+	// --------------- Act ---------------
+	result, _, err := mimage.Redact(context.Background(), mimage.BytesSource{Data: data}, []mimage.PlotDataModel{
+		{Rect: image.Rect(10, 10, 50, 50), Score: 0.9},
+		{Rect: image.Rect(100, 100, 150, 150), Score: 0.1},
+	}, mimage.Redaction{Mode: mimage.RedactFill}, mimage.WithMinScore(0.5))
+	_, _, strictErr := mimage.Redact(context.Background(), mimage.BytesSource{Data: data}, []mimage.PlotDataModel{
+		{Rect: image.Rect(150, 150, 250, 250)},
+	}, mimage.Redaction{}, mimage.WithStrict())
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	img, _, err := image.Decode(bytes.NewReader(result))
+	assert.NoError(t, err)
+	assert.Equal(t, color.RGBA{0, 0, 0, 255}, color.RGBAModel.Convert(img.At(31, 30)))
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, color.RGBAModel.Convert(img.At(120, 120)))
+	assert.ErrorIs(t, strictErr, mimage.ErrInvalidPlot)
+}
+
+func TestRedactMaskAndEmptyPlots(t *testing.T) {
+	// --------------- Arrange ---------------
+	_, data := createCheckerImage()
+	mask := image.NewGray(image.Rect(0, 0, 4, 4))
+	mask.SetGray(2, 1, color.Gray{Y: 1})
+	hidden := mimage.Keypoints{Points: []mimage.Keypoint{{At: image.Pt(5, 5), Hidden: true}}}
+
+	// --------------- Act ---------------
+	result, _, err := mimage.Redact(context.Background(), mimage.BytesSource{Data: data}, []mimage.PlotDataModel{
+		{},
+		{Shape: hidden},
+		{Mask: &mimage.Mask{Data: mask, Rect: image.Rect(100, 100, 200, 200)}},
+	}, mimage.Redaction{Mode: mimage.RedactFill, Color: color.RGBA{0, 0, 255, 255}})
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	img, _, err := image.Decode(bytes.NewReader(result))
+	assert.NoError(t, err)
+	blue := color.RGBA{0, 0, 255, 255}
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, color.RGBAModel.Convert(img.At(0, 0)))
+	assert.Equal(t, blue, color.RGBAModel.Convert(img.At(150, 125)))
+	assert.Equal(t, blue, color.RGBAModel.Convert(img.At(174, 149)))
+	assert.NotEqual(t, blue, color.RGBAModel.Convert(img.At(149, 125)))
+	assert.NotEqual(t, blue, color.RGBAModel.Convert(img.At(175, 125)))
+	assert.NotEqual(t, blue, color.RGBAModel.Convert(img.At(150, 150)))
+}
@@ -0,0 +1,125 @@
+package mimage
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/draw"
+	"math"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// วิธีปรับภาพให้ได้ขนาดที่ต้องการใน Resize
+type ResizeMode int
+
+const (
+	ResizeFit     ResizeMode = iota // ย่อขยายให้อยู่ในขนาดที่กำหนดโดยคงสัดส่วน ภาพผลลัพธ์อาจเล็กกว่าที่กำหนดด้านหนึ่ง
+	ResizeFill                      // ย่อขยายให้เต็มขนาดที่กำหนดโดยคงสัดส่วน แล้วตัดส่วนที่เกินออกเท่ากันทั้งสองข้าง
+	ResizeStretch                   // ยืดให้ได้ขนาดที่กำหนดพอดีโดยไม่คงสัดส่วน
+)
+
+// วิธีคำนวณสีของ pixel ตอนย่อขยายภาพ
+type Interpolation int
+
+const (
+	InterpCatmullRom Interpolation = iota // คมชัดที่สุดแต่ช้าที่สุด
+	InterpBilinear                        // เร็วและนุ่มนวล
+	InterpNearest                         // เร็วที่สุด เหมาะกับ mask หรือภาพ pixel art
+)
+
+func (i Interpolation) scaler() xdraw.Scaler {
+	switch i {
+	case InterpBilinear:
+		return xdraw.BiLinear
+	case InterpNearest:
+		return xdraw.NearestNeighbor
+	default:
+		return xdraw.CatmullRom
+	}
+}
+
+// สำหรับย่อขยายภาพเป็นขนาด width x height ตาม mode
+// ถ้า width หรือ height เป็น 0 จะคำนวณด้านนั้นจากสัดส่วนเดิมแบบ ResizeFit ไม่ว่า mode จะเป็นอะไร
+func Resize(img image.Image, width, height int, mode ResizeMode, interp Interpolation) *image.RGBA {
+	src := img.Bounds()
+	if src.Empty() || width <= 0 && height <= 0 {
+		return toRGBA(img)
+	}
+	sw, sh := float64(src.Dx()), float64(src.Dy())
+
+	switch {
+	case mode == ResizeStretch && width > 0 && height > 0:
+	case mode == ResizeFill && width > 0 && height > 0:
+		// ตัดภาพต้นฉบับให้มีสัดส่วนเท่ากับผลลัพธ์ก่อนย่อขยาย
+		scale := math.Max(float64(width)/sw, float64(height)/sh)
+		cw, ch := int(math.Round(float64(width)/scale)), int(math.Round(float64(height)/scale))
+		min := src.Min.Add(image.Pt((src.Dx()-cw)/2, (src.Dy()-ch)/2))
+		src = image.Rectangle{Min: min, Max: min.Add(image.Pt(cw, ch))}.Intersect(img.Bounds())
+	default:
+		scale := math.Inf(1)
+		if width > 0 {
+			scale = float64(width) / sw
+		}
+		if height > 0 {
+			scale = math.Min(scale, float64(height)/sh)
+		}
+		width, height = max(int(math.Round(sw*scale)), 1), max(int(math.Round(sh*scale)), 1)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	interp.scaler().Scale(dst, dst.Bounds(), img, src, draw.Src, nil)
+	return dst
+}
+
+// สำหรับสร้างภาพย่อจาก src ขนาดไม่เกิน width x height ตาม mode แล้ว encode กลับเป็น []byte
+// ใช้ WithInterpolation และ option ของการ encode ได้
+func Thumbnail(ctx context.Context, src Source, width, height int, mode ResizeMode, opts ...Option) (result []byte, format string, err error) {
+	o := newOptions(opts)
+	if err := o.validate(); err != nil {
+		return nil, "", err
+	}
+
+	img, t, err := LoadImage(ctx, src)
+	if err != nil {
+		return nil, "", err
+	}
+
+	buf := new(bytes.Buffer)
+	format, err = encodeImage(buf, Resize(img, width, height, mode, o.interpolation), t, o)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), format, nil
+}
+
+// สำหรับย่อภาพผลลัพธ์ของ PlotImage ให้ด้านที่ยาวที่สุดไม่เกิน size pixel (ไม่ขยายภาพที่เล็กกว่า)
+// กรอบทั้งหมดถูกย่อตามภาพก่อนวาด เส้นและตัวอักษรจึงยังคมชัด
+func WithMaxDimension(size int) Option {
+	return func(o *options) {
+		o.maxDimension = size
+	}
+}
+
+// สำหรับกำหนดวิธี interpolation ของ WithMaxDimension และ Thumbnail (default: InterpCatmullRom)
+func WithInterpolation(interp Interpolation) Option {
+	return func(o *options) {
+		o.interpolation = interp
+	}
+}
+
+// ย่อ img ตาม WithMaxDimension พร้อมแปลงพิกัดของ plots ให้ตรงกับภาพที่ย่อแล้ว
+func (o *options) downscale(img *image.RGBA, plots []PlotDataModel) (*image.RGBA, []PlotDataModel) {
+	b := img.Bounds()
+	if o.maxDimension <= 0 || max(b.Dx(), b.Dy()) <= o.maxDimension {
+		return img, plots
+	}
+
+	resized := Resize(img, o.maxDimension, o.maxDimension, ResizeFit, o.interpolation)
+	t := Transform{
+		ScaleX: float64(resized.Bounds().Dx()) / float64(b.Dx()),
+		ScaleY: float64(resized.Bounds().Dy()) / float64(b.Dy()),
+	}
+	return resized, t.Plots(plots)
+}
@@ -0,0 +1,110 @@
+package mimage_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+// ภาพ 200x100 ครึ่งซ้ายสีแดง ครึ่งขวาสีน้ำเงิน
+func createSplitImage() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	draw.Draw(img, image.Rect(0, 0, 100, 100), image.NewUniform(color.RGBA{255, 0, 0, 255}), image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(100, 0, 200, 100), image.NewUniform(color.RGBA{0, 0, 255, 255}), image.Point{}, draw.Src)
+	return img
+}
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Width, Height int
+		Mode          mimage.ResizeMode
+		Interp        mimage.Interpolation
+		Expected      image.Rectangle
+	}{
+		{Name: "Fit keeps aspect ratio", Width: 100, Height: 100, Mode: mimage.ResizeFit, Expected: image.Rect(0, 0, 100, 50)},
+		{Name: "Fit with only width", Width: 50, Mode: mimage.ResizeFit, Interp: mimage.InterpBilinear, Expected: image.Rect(0, 0, 50, 25)},
+		{Name: "Fit with only height", Height: 300, Mode: mimage.ResizeFill, Expected: image.Rect(0, 0, 600, 300)},
+		{Name: "Fill crops to exact size", Width: 100, Height: 100, Mode: mimage.ResizeFill, Interp: mimage.InterpNearest, Expected: image.Rect(0, 0, 100, 100)},
+		{Name: "Stretch", Width: 50, Height: 80, Mode: mimage.ResizeStretch, Expected: image.Rect(0, 0, 50, 80)},
+		{Name: "No size keeps the image", Mode: mimage.ResizeStretch, Expected: image.Rect(0, 0, 200, 100)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result := mimage.Resize(createSplitImage(), tt.Width, tt.Height, tt.Mode, tt.Interp)
+
+			// --------------- Assert ---------------
+			assert.Equal(t, tt.Expected, result.Bounds())
+			w := result.Bounds().Dx()
+			assert.Equal(t, color.RGBA{255, 0, 0, 255}, result.RGBAAt(w/8, result.Bounds().Dy()/2))
+			assert.Equal(t, color.RGBA{0, 0, 255, 255}, result.RGBAAt(w-1-w/8, result.Bounds().Dy()/2))
+		})
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	// --------------- Act ---------------
+	result, format, err := mimage.Thumbnail(context.Background(), mimage.BytesSource{Data: createTestImage("bmp")}, 64, 64, mimage.ResizeFit, mimage.WithFormat("jpeg"))
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	img, _, err := image.Decode(bytes.NewReader(result))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 64, 64), img.Bounds())
+}
+
+func TestPlotImageWithMaxDimension(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Max      int
+		Expected image.Rectangle
+		Border   image.Point
+	}{
+		{Name: "Downscale and scale boxes", Max: 100, Expected: image.Rect(0, 0, 100, 100), Border: image.Pt(20, 30)},
+		{Name: "Smaller image is not upscaled", Max: 400, Expected: image.Rect(0, 0, 200, 200), Border: image.Pt(40, 60)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{
+				{Rect: image.Rect(40, 40, 160, 160), Style: mimage.PlotStyle{Thickness: 1}},
+				{Shape: mimage.Circle{Center: image.Pt(100, 100), Radius: 20}, Style: mimage.PlotStyle{Thickness: 1, Color: color.RGBA{0, 0, 255, 255}}},
+			}, mimage.WithMaxDimension(tt.Max), mimage.WithInterpolation(mimage.InterpNearest))
+
+			// --------------- Assert ---------------
+			assert.NoError(t, err)
+			img, _, err := image.Decode(bytes.NewReader(result))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.Expected, img.Bounds())
+			assert.Equal(t, color.RGBA{255, 0, 0, 255}, color.RGBAModel.Convert(img.At(tt.Border.X, tt.Border.Y)))
+			scale := tt.Expected.Dx() / 10
+			assert.Equal(t, color.RGBA{0, 0, 255, 255}, color.RGBAModel.Convert(img.At(scale*4, scale*5)))
+		})
+	}
+}
+
+func TestPlotImageWithMaxDimensionAndStrict(t *testing.T) {
+	// --------------- Arrange ---------------
+	buf := new(bytes.Buffer)
+	png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 100, 100)))
+
+	// --------------- Act ---------------
+	result, err := mimage.PlotImageFromBytes(buf.Bytes(), []mimage.PlotDataModel{
+		{Rect: image.Rect(0, 0, 99, 99), Style: mimage.PlotStyle{Thickness: 1}},
+	}, mimage.WithMaxDimension(50), mimage.WithStrict())
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	img, _, err := image.Decode(bytes.NewReader(result))
+	assert.NoError(t, err)
+	assert.Equal(t, color.RGBA{255, 0, 0, 255}, color.RGBAModel.Convert(img.At(49, 25)))
+}
@@ -0,0 +1,147 @@
+package mimage
+
+import (
+	"image"
+	"image/draw"
+	"math"
+)
+
+// รูปทรงที่วาดแทนกรอบสี่เหลี่ยมได้ โดยกำหนดผ่าน PlotDataModel.Shape
+// ใช้ style และ label แบบเดียวกับกรอบสี่เหลี่ยม
+type Shape interface {
+	// ขอบเขตของรูปทรงแบบเดียวกับ PlotDataModel.Rect (Max คือ pixel สุดท้ายที่วาด)
+	// ใช้สำหรับวาง label, filter กรอบที่อยู่นอกภาพ และตรวจสอบใน strict mode
+	Bounds() image.Rectangle
+
+	// วาดรูปทรงลงบน dst ด้วย style ที่เติมค่า default แล้ว
+	Draw(dst draw.Image, style PlotStyle)
+}
+
+// รูปหลายเหลี่ยมปิด เช่นเส้นขอบของ segmentation หรือป้ายทะเบียน ระบายพื้นด้วย FillColor ได้
+type Polygon struct {
+	Points []image.Point
+}
+
+func (s Polygon) Bounds() image.Rectangle {
+	return pointsBounds(s.Points)
+}
+
+func (s Polygon) Transform(t Transform) Shape {
+	return Polygon{Points: t.Points(s.Points)}
+}
+
+func (s Polygon) Draw(dst draw.Image, style PlotStyle) {
+	if style.FillColor != nil {
+		fillPolygon(dst, s.Points, style.FillColor)
+	}
+	drawPolyline(dst, s.Points, true, style.Thickness, style.Color)
+}
+
+// เส้นต่อกันแบบไม่ปิด เช่นเส้นทางของวัตถุที่ track ไว้
+type Polyline struct {
+	Points []image.Point
+}
+
+func (s Polyline) Bounds() image.Rectangle {
+	return pointsBounds(s.Points)
+}
+
+func (s Polyline) Transform(t Transform) Shape {
+	return Polyline{Points: t.Points(s.Points)}
+}
+
+func (s Polyline) Draw(dst draw.Image, style PlotStyle) {
+	drawPolyline(dst, s.Points, false, style.Thickness, style.Color)
+}
+
+// วงกลมรัศมี Radius รอบ Center เส้นขอบอยู่ด้านในของรัศมี ระบายพื้นด้วย FillColor ได้
+type Circle struct {
+	Center image.Point
+	Radius int
+}
+
+func (s Circle) Bounds() image.Rectangle {
+	return image.Rect(s.Center.X-s.Radius, s.Center.Y-s.Radius, s.Center.X+s.Radius, s.Center.Y+s.Radius)
+}
+
+// รัศมีถูก scale ด้วยค่าเฉลี่ยของ ScaleX และ ScaleY
+func (s Circle) Transform(t Transform) Shape {
+	scale := (math.Abs(t.ScaleX) + math.Abs(t.ScaleY)) / 2
+	return Circle{Center: t.Point(s.Center), Radius: int(math.Round(float64(s.Radius) * scale))}
+}
+
+func (s Circle) Draw(dst draw.Image, style PlotStyle) {
+	if style.FillColor != nil {
+		drawRing(dst, s.Center, s.Radius-style.Thickness, s.Radius, style.FillColor)
+	}
+	drawRing(dst, s.Center, s.Radius, style.Thickness, style.Color)
+}
+
+// จุดทึบที่ตำแหน่ง At ด้วยสีเส้นกรอบ (default Radius: ความหนาเส้น * 2)
+type Point struct {
+	At     image.Point
+	Radius int
+}
+
+func (s Point) Bounds() image.Rectangle {
+	return image.Rectangle{Min: s.At, Max: s.At}
+}
+
+// ขนาดของจุดไม่ถูก scale
+func (s Point) Transform(t Transform) Shape {
+	return Point{At: t.Point(s.At), Radius: s.Radius}
+}
+
+func (s Point) Draw(dst draw.Image, style PlotStyle) {
+	r := s.Radius
+	if r <= 0 {
+		r = style.Thickness * 2
+	}
+	drawRing(dst, s.At, r, r+1, style.Color)
+}
+
+// ลูกศรจาก From ไปยัง To โดยหัวลูกศรเป็นสามเหลี่ยมทึบที่ To
+// (default HeadSize: ความหนาเส้น * 4 อย่างน้อย 6 pixel)
+type Arrow struct {
+	From, To image.Point
+	HeadSize int
+}
+
+func (s Arrow) Bounds() image.Rectangle {
+	return pointsBounds([]image.Point{s.From, s.To})
+}
+
+func (s Arrow) Transform(t Transform) Shape {
+	return Arrow{From: t.Point(s.From), To: t.Point(s.To), HeadSize: s.HeadSize}
+}
+
+func (s Arrow) Draw(dst draw.Image, style PlotStyle) {
+	head := s.head(style.Thickness)
+	// เส้นหยุดที่ฐานของหัวลูกศร ไม่ให้เส้นหนาล้นปลายแหลม
+	base := image.Pt((head[1].X+head[2].X)/2, (head[1].Y+head[2].Y)/2)
+	drawLine(dst, s.From, base, style.Thickness, style.Color)
+	fillPolygon(dst, head, style.Color)
+	drawPolyline(dst, head, true, 1, style.Color)
+}
+
+// คืนค่าจุดปลายแหลมและมุมทั้งสองของหัวลูกศร
+func (s Arrow) head(thickness int) []image.Point {
+	size := s.HeadSize
+	if size <= 0 {
+		size = max(thickness*4, 6)
+	}
+
+	dx, dy := float64(s.To.X-s.From.X), float64(s.To.Y-s.From.Y)
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return []image.Point{s.To, s.To, s.To}
+	}
+	ux, uy := dx/length, dy/length
+	bx, by := float64(s.To.X)-ux*float64(size), float64(s.To.Y)-uy*float64(size)
+	half := float64(size) / 2
+	return []image.Point{
+		s.To,
+		image.Pt(int(math.Round(bx-uy*half)), int(math.Round(by+ux*half))),
+		image.Pt(int(math.Round(bx+uy*half)), int(math.Round(by-ux*half))),
+	}
+}
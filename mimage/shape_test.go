@@ -0,0 +1,107 @@
+package mimage_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlotImageWithShape(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	white := color.RGBA{255, 255, 255, 255}
+	tests := []struct {
+		Name   string
+		Shape  mimage.Shape
+		Style  mimage.PlotStyle
+		Points map[image.Point]color.RGBA
+	}{
+		{
+			Name:   "Polygon outline",
+			Shape:  mimage.Polygon{Points: []image.Point{{20, 20}, {100, 20}, {60, 100}}},
+			Points: map[image.Point]color.RGBA{{60, 20}: red, {60, 100}: red, {60, 50}: white},
+		},
+		{
+			Name:   "Polygon fill",
+			Shape:  mimage.Polygon{Points: []image.Point{{20, 20}, {100, 20}, {60, 100}}},
+			Style:  mimage.PlotStyle{FillColor: color.RGBA{0, 0, 255, 255}},
+			Points: map[image.Point]color.RGBA{{60, 50}: {0, 0, 255, 255}, {20, 90}: white},
+		},
+		{
+			Name:   "Polyline is not closed",
+			Shape:  mimage.Polyline{Points: []image.Point{{20, 100}, {100, 100}, {100, 180}}},
+			Points: map[image.Point]color.RGBA{{60, 100}: red, {100, 140}: red, {60, 140}: white},
+		},
+		{
+			Name:   "Circle outline",
+			Shape:  mimage.Circle{Center: image.Pt(100, 100), Radius: 40},
+			Points: map[image.Point]color.RGBA{{60, 100}: red, {100, 140}: red, {100, 100}: white},
+		},
+		{
+			Name:   "Point",
+			Shape:  mimage.Point{At: image.Pt(100, 100), Radius: 3},
+			Points: map[image.Point]color.RGBA{{100, 100}: red, {103, 100}: red, {105, 100}: white},
+		},
+		{
+			Name:   "Arrow",
+			Shape:  mimage.Arrow{From: image.Pt(20, 100), To: image.Pt(180, 100), HeadSize: 20},
+			Points: map[image.Point]color.RGBA{{100, 100}: red, {165, 95}: red, {179, 100}: red, {100, 110}: white},
+		},
+		{
+			Name:   "Shape partly outside the image",
+			Shape:  mimage.Polyline{Points: []image.Point{{-50, 150}, {250, 150}}},
+			Points: map[image.Point]color.RGBA{{0, 150}: red, {199, 150}: red},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{
+				{Shape: tt.Shape, Style: tt.Style},
+			})
+
+			// --------------- Assert ---------------
+			assert.NoError(t, err)
+			img, _, err := image.Decode(bytes.NewReader(result))
+			assert.NoError(t, err)
+			for p, expected := range tt.Points {
+				assert.Equal(t, expected, color.RGBAModel.Convert(img.At(p.X, p.Y)), "pixel %v", p)
+			}
+		})
+	}
+}
+
+func TestPlotImageShapeWithLabel(t *testing.T) {
+	// --------------- Act ---------------
+	result, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{
+		{Shape: mimage.Point{At: image.Pt(100, 100)}, Label: "nose", Style: mimage.PlotStyle{FontSize: 16}},
+		{Shape: mimage.Polygon{Points: []image.Point{{20, 150}, {60, 150}, {40, 190}}}, Label: "plate", Style: mimage.PlotStyle{FontSize: 16}},
+	})
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	img, _, err := image.Decode(bytes.NewReader(result))
+	assert.NoError(t, err)
+	// ป้าย label อยู่เหนือขอบเขตของรูปทรง
+	assert.Equal(t, color.RGBA{255, 0, 0, 255}, color.RGBAModel.Convert(img.At(100, 95)))
+	assert.Equal(t, color.RGBA{255, 0, 0, 255}, color.RGBAModel.Convert(img.At(21, 145)))
+}
+
+func TestPlotImageStrictShape(t *testing.T) {
+	// --------------- Act ---------------
+	_, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{
+		{Shape: mimage.Point{At: image.Pt(10, 10)}},
+		{Shape: mimage.Polyline{Points: []image.Point{{0, 50}, {199, 50}}}},
+		{Shape: mimage.Circle{Center: image.Pt(190, 190), Radius: 20}},
+	}, mimage.WithStrict())
+
+	// --------------- Assert ---------------
+	var verr *mimage.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Len(t, verr.Invalid, 1)
+	assert.Equal(t, 2, verr.Invalid[0].Index)
+}
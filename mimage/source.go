@@ -0,0 +1,102 @@
+package mimage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// error เมื่อ data URI ไม่ถูกต้องตามรูปแบบ data:[<mediatype>][;base64],<data>
+var ErrInvalidDataURI = errors.New("invalid data uri")
+
+// สำหรับเป็นแหล่งที่มาของภาพให้ PlotImage สามารถ implement เองเพื่อรองรับแหล่งอื่น ๆ ได้ (เช่น blob store)
+type Source interface {
+	Open(ctx context.Context) (io.ReadCloser, error)
+}
+
+// สำหรับแปลง function ธรรมดาให้เป็น Source
+type SourceFunc func(ctx context.Context) (io.ReadCloser, error)
+
+func (f SourceFunc) Open(ctx context.Context) (io.ReadCloser, error) {
+	return f(ctx)
+}
+
+// สำหรับอ่านภาพจาก []byte
+type BytesSource struct {
+	Data []byte
+}
+
+func (s BytesSource) Open(ctx context.Context) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(s.Data)), nil
+}
+
+// สำหรับอ่านภาพจากไฟล์
+type FileSource struct {
+	Path string
+}
+
+func (s FileSource) Open(ctx context.Context) (io.ReadCloser, error) {
+	return os.Open(s.Path)
+}
+
+// สำหรับอ่านภาพจาก io.Reader ถ้า Reader เป็น io.ReadCloser จะถูกปิดเมื่ออ่านเสร็จ
+type ReaderSource struct {
+	Reader io.Reader
+}
+
+func (s ReaderSource) Open(ctx context.Context) (io.ReadCloser, error) {
+	if rc, ok := s.Reader.(io.ReadCloser); ok {
+		return rc, nil
+	}
+	return io.NopCloser(s.Reader), nil
+}
+
+// สำหรับอ่านภาพจาก url ถ้าไม่ได้กำหนด Fetcher จะใช้ DefaultFetcher
+type URLSource struct {
+	URL     string
+	Fetcher Fetcher
+}
+
+func (s URLSource) Open(ctx context.Context) (io.ReadCloser, error) {
+	fetcher := s.Fetcher
+	if fetcher == nil {
+		fetcher = DefaultFetcher
+	}
+
+	data, err := fetcher.Fetch(ctx, s.URL)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// สำหรับอ่านภาพจาก data URI เช่น "data:image/png;base64,iVBORw0KGgo..."
+type DataURISource struct {
+	URI string
+}
+
+func (s DataURISource) Open(ctx context.Context) (io.ReadCloser, error) {
+	rest, ok := strings.CutPrefix(s.URI, "data:")
+	if !ok {
+		return nil, ErrInvalidDataURI
+	}
+	meta, data, ok := strings.Cut(rest, ",")
+	if !ok {
+		return nil, ErrInvalidDataURI
+	}
+
+	if strings.HasSuffix(meta, ";base64") {
+		return io.NopCloser(base64.NewDecoder(base64.StdEncoding, strings.NewReader(data))), nil
+	}
+
+	decoded, err := url.PathUnescape(data)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidDataURI, err)
+	}
+	return io.NopCloser(strings.NewReader(decoded)), nil
+}
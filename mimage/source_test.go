@@ -0,0 +1,103 @@
+package mimage_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"image"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlotImage(t *testing.T) {
+	pngData := createTestImage("png")
+	file, err := os.ReadFile("../testdata/image_test.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Name           string
+		Source         mimage.Source
+		ExpectedFormat string
+		ExpectedError  bool
+	}{
+		{
+			Name:           "Bytes source",
+			Source:         mimage.BytesSource{Data: pngData},
+			ExpectedFormat: "png",
+		},
+		{
+			Name:           "File source",
+			Source:         mimage.FileSource{Path: "../testdata/image_test.jpg"},
+			ExpectedFormat: "jpeg",
+		},
+		{
+			Name:           "Reader source",
+			Source:         mimage.ReaderSource{Reader: bytes.NewReader(file)},
+			ExpectedFormat: "jpeg",
+		},
+		{
+			Name:           "Base64 data URI source",
+			Source:         mimage.DataURISource{URI: "data:image/png;base64," + base64.StdEncoding.EncodeToString(pngData)},
+			ExpectedFormat: "png",
+		},
+		{
+			Name: "Custom source",
+			Source: mimage.SourceFunc(func(ctx context.Context) (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(pngData)), nil
+			}),
+			ExpectedFormat: "png",
+		},
+		{
+			Name:          "Invalid data URI source",
+			Source:        mimage.DataURISource{URI: "image/png;base64,xxx"},
+			ExpectedError: true,
+		},
+		{
+			Name: "Custom source error",
+			Source: mimage.SourceFunc(func(ctx context.Context) (io.ReadCloser, error) {
+				return nil, errors.New("blob not found")
+			}),
+			ExpectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result, format, err := mimage.PlotImage(context.Background(), tt.Source, []mimage.PlotDataModel{
+				{
+					Rect:  image.Rect(10, 10, 50, 50),
+					Label: "Test Label 1",
+				},
+			})
+
+			// --------------- Assert ---------------
+			if tt.ExpectedError {
+				assert.Error(t, err)
+				assert.Zero(t, result)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.ExpectedFormat, format)
+			_, typeImgResult, err := image.Decode(bytes.NewReader(result))
+			assert.NoError(t, err)
+			assert.Equal(t, format, typeImgResult)
+		})
+	}
+}
+
+func TestLoadImage(t *testing.T) {
+	// --------------- Act ---------------
+	img, format, err := mimage.LoadImage(context.Background(), mimage.DataURISource{URI: "data:image/png;base64," + base64.StdEncoding.EncodeToString(createTestImage("png"))})
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.Equal(t, "png", format)
+	assert.Equal(t, image.Rect(0, 0, 200, 200), img.Bounds())
+}
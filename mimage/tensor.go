@@ -0,0 +1,152 @@
+package mimage
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+)
+
+// error เมื่อขนาดของภาพหรือ tensor ไม่ตรงกับ shape ที่ต้องการใน ToTensor และ FromTensor
+var ErrTensorShape = errors.New("invalid tensor shape")
+
+// ลำดับของมิติใน tensor
+type TensorLayout int
+
+const (
+	LayoutNCHW TensorLayout = iota // batch, channel, height, width (PyTorch, ONNX)
+	LayoutNHWC                     // batch, height, width, channel (TensorFlow)
+)
+
+// ลำดับของสีใน channel
+type ChannelOrder int
+
+const (
+	OrderRGB ChannelOrder = iota
+	OrderBGR              // OpenCV และ model ที่ train ด้วย OpenCV
+)
+
+// กำหนดรูปแบบของ tensor ใน ToTensor และ FromTensor
+// ค่าของแต่ละ channel คือ (pixel/255 - Mean) / Std เช่น ImageNet ใช้ Mean {0.485, 0.456, 0.406} และ Std {0.229, 0.224, 0.225}
+// Std ที่เป็น 0 จะถือเป็น 1 ดังนั้น zero value จะได้ค่า 0-1 ตามที่ YOLO ใช้
+type TensorOptions struct {
+	Layout TensorLayout
+	Order  ChannelOrder
+	Mean   [3]float32 // เรียงตาม Order
+	Std    [3]float32 // เรียงตาม Order
+}
+
+func (o TensorOptions) std(c int) float32 {
+	if o.Std[c] == 0 {
+		return 1
+	}
+	return o.Std[c]
+}
+
+// index ของ pixel x, y channel c ในภาพลำดับที่ n ตาม layout
+func (o TensorOptions) index(n, c, x, y, channels, w, h int) int {
+	if o.Layout == LayoutNHWC {
+		return ((n*h+y)*w+x)*channels + c
+	}
+	return ((n*channels+c)*h+y)*w + x
+}
+
+// สำหรับแปลงภาพหลายภาพที่มีขนาดเท่ากันเป็น tensor 3 channel ก้อนเดียว (batch)
+// shape เรียงตาม Layout เช่น NCHW คือ [N, 3, H, W] ภาพที่ขนาดไม่เท่ากันจะคืน ErrTensorShape
+func ToTensor(imgs []image.Image, opts TensorOptions) (data []float32, shape [4]int, err error) {
+	if len(imgs) == 0 {
+		return nil, shape, fmt.Errorf("%w: no images", ErrTensorShape)
+	}
+	size := imgs[0].Bounds().Size()
+	w, h := size.X, size.Y
+	if opts.Layout == LayoutNHWC {
+		shape = [4]int{len(imgs), h, w, 3}
+	} else {
+		shape = [4]int{len(imgs), 3, h, w}
+	}
+
+	// ตาราง lookup ของค่าแต่ละ pixel ต่อ channel แทนการคำนวณซ้ำทุก pixel
+	var lut [3][256]float32
+	for c := 0; c < 3; c++ {
+		for v := 0; v < 256; v++ {
+			lut[c][v] = (float32(v)/255 - opts.Mean[c]) / opts.std(c)
+		}
+	}
+	channel := [3]int{0, 1, 2}
+	if opts.Order == OrderBGR {
+		channel = [3]int{2, 1, 0}
+	}
+
+	data = make([]float32, len(imgs)*3*w*h)
+	for n, src := range imgs {
+		if src.Bounds().Size() != size {
+			return nil, [4]int{}, fmt.Errorf("%w: image %d is %v, expected %v", ErrTensorShape, n, src.Bounds().Size(), size)
+		}
+		img, ok := src.(*image.RGBA)
+		if !ok {
+			img = toRGBA(src)
+		}
+		b := img.Bounds()
+		for y := 0; y < h; y++ {
+			row := img.Pix[img.PixOffset(b.Min.X, b.Min.Y+y):]
+			for x := 0; x < w; x++ {
+				for c := 0; c < 3; c++ {
+					data[opts.index(n, c, x, y, 3, w, h)] = lut[c][row[x*4+channel[c]]]
+				}
+			}
+		}
+	}
+
+	return data, shape, nil
+}
+
+// สำหรับแปลง tensor กลับเป็นภาพ ขนาดของ batch คิดจาก len(data) / (channels * width * height)
+// channels เป็น 3 จะได้ *image.RGBA และเป็น 1 (เช่น heatmap หรือ mask ที่ model ตอบกลับ) จะได้ *image.Gray
+// โดยใช้ Mean[0] และ Std[0] ค่าที่เกินช่วง 0-255 จะถูกตัดให้อยู่ในช่วง
+func FromTensor(data []float32, width, height, channels int, opts TensorOptions) ([]image.Image, error) {
+	if channels != 1 && channels != 3 {
+		return nil, fmt.Errorf("%w: channels must be 1 or 3, got %d", ErrTensorShape, channels)
+	}
+	plane := width * height * channels
+	if width <= 0 || height <= 0 || len(data) == 0 || len(data)%plane != 0 {
+		return nil, fmt.Errorf("%w: %d values is not a multiple of %dx%dx%d", ErrTensorShape, len(data), channels, height, width)
+	}
+
+	channel := [3]int{0, 1, 2}
+	if opts.Order == OrderBGR {
+		channel = [3]int{2, 1, 0}
+	}
+	value := func(i, c int) uint8 {
+		v := (data[i]*opts.std(c) + opts.Mean[c]) * 255
+		return uint8(math.Max(0, math.Min(255, math.Round(float64(v)))))
+	}
+
+	imgs := make([]image.Image, len(data)/plane)
+	for n := range imgs {
+		rect := image.Rect(0, 0, width, height)
+		if channels == 1 {
+			img := image.NewGray(rect)
+			for y := 0; y < height; y++ {
+				for x := 0; x < width; x++ {
+					img.Pix[y*img.Stride+x] = value(opts.index(n, 0, x, y, 1, width, height), 0)
+				}
+			}
+			imgs[n] = img
+			continue
+		}
+
+		img := image.NewRGBA(rect)
+		for y := 0; y < height; y++ {
+			row := img.Pix[y*img.Stride:]
+			for x := 0; x < width; x++ {
+				for c := 0; c < 3; c++ {
+					row[x*4+channel[c]] = value(opts.index(n, c, x, y, 3, width, height), c)
+				}
+				row[x*4+3] = 0xff
+			}
+		}
+		imgs[n] = img
+	}
+
+	return imgs, nil
+}
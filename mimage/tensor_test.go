@@ -0,0 +1,126 @@
+package mimage_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+// ภาพ 2x1 pixel ซ้ายสี c1 ขวาสี c2
+func createPixelPair(c1, c2 color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.SetRGBA(0, 0, c1)
+	img.SetRGBA(1, 0, c2)
+	return img
+}
+
+func TestToTensor(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	blue := color.RGBA{0, 0, 255, 255}
+	tests := []struct {
+		Name          string
+		Images        []image.Image
+		Options       mimage.TensorOptions
+		Expected      []float32
+		ExpectedShape [4]int
+	}{
+		{
+			Name:          "NCHW RGB",
+			Images:        []image.Image{createPixelPair(red, blue)},
+			Expected:      []float32{1, 0, 0, 0, 0, 1},
+			ExpectedShape: [4]int{1, 3, 1, 2},
+		},
+		{
+			Name:          "NHWC BGR",
+			Images:        []image.Image{createPixelPair(red, blue)},
+			Options:       mimage.TensorOptions{Layout: mimage.LayoutNHWC, Order: mimage.OrderBGR},
+			Expected:      []float32{0, 0, 1, 1, 0, 0},
+			ExpectedShape: [4]int{1, 1, 2, 3},
+		},
+		{
+			Name:          "Mean and std",
+			Images:        []image.Image{createPixelPair(red, blue)},
+			Options:       mimage.TensorOptions{Mean: [3]float32{0.5, 0.5, 0.5}, Std: [3]float32{0.5, 0.5, 0.5}},
+			Expected:      []float32{1, -1, -1, -1, -1, 1},
+			ExpectedShape: [4]int{1, 3, 1, 2},
+		},
+		{
+			Name: "Batch of non RGBA images",
+			Images: []image.Image{
+				&image.Gray{Pix: []uint8{255}, Stride: 1, Rect: image.Rect(0, 0, 1, 1)},
+				createPixelPair(red, blue).SubImage(image.Rect(1, 0, 2, 1)),
+			},
+			Expected:      []float32{1, 1, 1, 0, 0, 1},
+			ExpectedShape: [4]int{2, 3, 1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result, shape, err := mimage.ToTensor(tt.Images, tt.Options)
+
+			// --------------- Assert ---------------
+			assert.NoError(t, err)
+			assert.Equal(t, tt.ExpectedShape, shape)
+			assert.InDeltaSlice(t, tt.Expected, result, 1e-6)
+		})
+	}
+}
+
+func TestToTensorErrors(t *testing.T) {
+	// --------------- Act ---------------
+	_, _, errEmpty := mimage.ToTensor(nil, mimage.TensorOptions{})
+	_, _, errSize := mimage.ToTensor([]image.Image{image.NewRGBA(image.Rect(0, 0, 2, 2)), image.NewRGBA(image.Rect(0, 0, 3, 2))}, mimage.TensorOptions{})
+
+	// --------------- Assert ---------------
+	assert.ErrorIs(t, errEmpty, mimage.ErrTensorShape)
+	assert.ErrorIs(t, errSize, mimage.ErrTensorShape)
+}
+
+func TestFromTensorRoundTrip(t *testing.T) {
+	opts := []mimage.TensorOptions{
+		{},
+		{Layout: mimage.LayoutNHWC, Order: mimage.OrderBGR},
+		{Mean: [3]float32{0.485, 0.456, 0.406}, Std: [3]float32{0.229, 0.224, 0.225}},
+	}
+	for _, o := range opts {
+		// --------------- Arrange ---------------
+		imgs := []image.Image{
+			createPixelPair(color.RGBA{10, 20, 30, 255}, color.RGBA{200, 150, 100, 255}),
+			createPixelPair(color.RGBA{0, 255, 128, 255}, color.RGBA{1, 2, 3, 255}),
+		}
+		data, _, err := mimage.ToTensor(imgs, o)
+		assert.NoError(t, err)
+
+		// --------------- Act ---------------
+		result, err := mimage.FromTensor(data, 2, 1, 3, o)
+
+		// --------------- Assert ---------------
+		assert.NoError(t, err)
+		assert.Equal(t, imgs, result)
+	}
+}
+
+func TestFromTensorHeatmap(t *testing.T) {
+	// --------------- Act ---------------
+	result, err := mimage.FromTensor([]float32{0, 0.5, 1, 2, -1, 0.25}, 3, 1, 1, mimage.TensorOptions{})
+
+	// --------------- Assert ---------------
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, []uint8{0, 128, 255}, result[0].(*image.Gray).Pix)
+	assert.Equal(t, []uint8{255, 0, 64}, result[1].(*image.Gray).Pix)
+}
+
+func TestFromTensorErrors(t *testing.T) {
+	// --------------- Act ---------------
+	_, errChannels := mimage.FromTensor(make([]float32, 8), 2, 2, 2, mimage.TensorOptions{})
+	_, errLength := mimage.FromTensor(make([]float32, 7), 2, 2, 1, mimage.TensorOptions{})
+
+	// --------------- Assert ---------------
+	assert.ErrorIs(t, errChannels, mimage.ErrTensorShape)
+	assert.ErrorIs(t, errLength, mimage.ErrTensorShape)
+}
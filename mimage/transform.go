@@ -0,0 +1,98 @@
+package mimage
+
+import (
+	"image"
+	"math"
+)
+
+// การแปลงพิกัดแบบ scale แล้วเลื่อน: x' = x*ScaleX + OffsetX, y' = y*ScaleY + OffsetY
+// ใช้ปรับตำแหน่งของกรอบเมื่อภาพถูกย่อขยายหรือเติมขอบ
+type Transform struct {
+	ScaleX, ScaleY   float64
+	OffsetX, OffsetY float64
+}
+
+// Shape ที่ implement interface นี้จะถูกแปลงพิกัดไปพร้อมกับภาพ (เช่นเมื่อใช้ WithMaxDimension)
+// Shape ที่ไม่ได้ implement จะถูกวาดตามพิกัดเดิม
+type TransformableShape interface {
+	Shape
+	Transform(t Transform) Shape
+}
+
+// การแปลงที่ไม่เปลี่ยนพิกัด
+var IdentityTransform = Transform{ScaleX: 1, ScaleY: 1}
+
+// สำหรับแปลงพิกัดทศนิยม
+func (t Transform) Apply(x, y float64) (float64, float64) {
+	return x*t.ScaleX + t.OffsetX, y*t.ScaleY + t.OffsetY
+}
+
+// สำหรับแปลงจุด โดยปัดเศษเป็น pixel ที่ใกล้ที่สุด
+func (t Transform) Point(p image.Point) image.Point {
+	x, y := t.Apply(float64(p.X), float64(p.Y))
+	return image.Pt(int(math.Round(x)), int(math.Round(y)))
+}
+
+// สำหรับแปลงจุดทุกจุดใน points เป็น slice ใหม่
+func (t Transform) Points(points []image.Point) []image.Point {
+	out := make([]image.Point, len(points))
+	for i, p := range points {
+		out[i] = t.Point(p)
+	}
+	return out
+}
+
+// สำหรับแปลงกรอบ โดยแปลงมุม Min และ Max (Max ไม่รวม pixel นั้นแบบ image.Rectangle)
+func (t Transform) Rect(r image.Rectangle) image.Rectangle {
+	return image.Rectangle{Min: t.Point(r.Min), Max: t.Point(r.Max)}
+}
+
+// สำหรับแปลง PlotDataModel.Rect ที่ Max เป็น pixel สุดท้าย โดยแปลงขอบด้านนอกของ pixel นั้นแล้วลบ 1 กลับ
+// rect ที่ไม่ได้กำหนด (zero value) จะคงเดิม และ rect ที่กลับด้านจะถูกแปลงแบบเดียวกับ Rect
+func (t Transform) plotRect(r image.Rectangle) image.Rectangle {
+	switch {
+	case r == image.Rectangle{}:
+		return r
+	case r.Min.X > r.Max.X || r.Min.Y > r.Max.Y:
+		return t.Rect(r)
+	}
+	r = t.Rect(inclusive(r)).Canon()
+	r.Max = image.Pt(max(r.Max.X-1, r.Min.X), max(r.Max.Y-1, r.Min.Y))
+	return r
+}
+
+// การแปลงย้อนกลับ
+func (t Transform) Invert() Transform {
+	return Transform{
+		ScaleX:  1 / t.ScaleX,
+		ScaleY:  1 / t.ScaleY,
+		OffsetX: -t.OffsetX / t.ScaleX,
+		OffsetY: -t.OffsetY / t.ScaleY,
+	}
+}
+
+// สำหรับแปลงพิกัดของทุกกรอบใน plots (Rect, Box แบบ pixel, Shape และ Mask.Rect) โดยไม่แก้ไข slice ต้นฉบับ
+// Box แบบสัดส่วนจะคงค่าเดิม เพราะถูกคำนวณจากขนาดของภาพตอนวาดอยู่แล้ว
+func (t Transform) Plots(plots []PlotDataModel) []PlotDataModel {
+	out := make([]PlotDataModel, len(plots))
+	for i, p := range plots {
+		p.Rect = t.plotRect(p.Rect)
+		if p.Box != nil && !p.Box.Normalized {
+			x1, y1, x2, y2 := p.Box.xyxy()
+			x1, y1 = t.Apply(x1, y1)
+			x2, y2 = t.Apply(x2, y2)
+			box := NewBox(FormatXYXY, false, x1, y1, x2, y2).To(p.Box.Format)
+			p.Box = &box
+		}
+		if s, ok := p.Shape.(TransformableShape); ok {
+			p.Shape = s.Transform(t)
+		}
+		if p.Mask != nil && !p.Mask.Rect.Empty() {
+			mask := *p.Mask
+			mask.Rect = t.Rect(mask.Rect)
+			p.Mask = &mask
+		}
+		out[i] = p
+	}
+	return out
+}
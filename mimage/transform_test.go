@@ -0,0 +1,58 @@
+package mimage_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformPlots(t *testing.T) {
+	// --------------- Arrange ---------------
+	tr := mimage.Transform{ScaleX: 0.5, ScaleY: 0.5, OffsetX: 10, OffsetY: 20}
+	pixel := mimage.NewBox(mimage.FormatXYWH, false, 100, 100, 40, 20)
+	normalized := mimage.NewBox(mimage.FormatCXCYWH, true, 0.5, 0.5, 0.2, 0.2)
+	plots := []mimage.PlotDataModel{
+		{Rect: image.Rect(0, 0, 100, 50), Label: "face"},
+		{Box: &pixel},
+		{Box: &normalized},
+		{Shape: mimage.Polygon{Points: []image.Point{{0, 0}, {20, 0}, {20, 20}}}},
+		{Shape: mimage.Circle{Center: image.Pt(100, 100), Radius: 40}},
+		{Shape: mimage.Keypoints{Points: []mimage.Keypoint{{At: image.Pt(40, 60), Score: 0.8}}}},
+		{Shape: mimage.OrientedBox{CX: 100, CY: 100, Width: 60, Height: 20, Angle: 30}},
+		{Mask: &mimage.Mask{Rect: image.Rect(0, 0, 200, 200)}},
+	}
+
+	// --------------- Act ---------------
+	result := tr.Plots(plots)
+
+	// --------------- Assert ---------------
+	assert.Equal(t, image.Rect(10, 20, 60, 45), result[0].Rect)
+	assert.Equal(t, "face", result[0].Label)
+	assert.Equal(t, mimage.NewBox(mimage.FormatXYWH, false, 60, 70, 20, 10), *result[1].Box)
+	assert.Equal(t, normalized, *result[2].Box)
+	assert.Equal(t, mimage.Polygon{Points: []image.Point{{10, 20}, {20, 20}, {20, 30}}}, result[3].Shape)
+	assert.Equal(t, mimage.Circle{Center: image.Pt(60, 70), Radius: 20}, result[4].Shape)
+	assert.Equal(t, []mimage.Keypoint{{At: image.Pt(30, 50), Score: 0.8}}, result[5].Shape.(mimage.Keypoints).Points)
+	assert.Equal(t, mimage.OrientedBox{CX: 60, CY: 70, Width: 30, Height: 10, Angle: 30}, result[6].Shape)
+	assert.Equal(t, image.Rect(10, 20, 110, 120), result[7].Mask.Rect)
+	assert.Equal(t, image.Rectangle{}, result[7].Rect)
+	// ต้นฉบับไม่ถูกแก้ไข
+	assert.Equal(t, image.Rect(0, 0, 100, 50), plots[0].Rect)
+	assert.Equal(t, mimage.NewBox(mimage.FormatXYWH, false, 100, 100, 40, 20), pixel)
+	assert.Equal(t, image.Rect(0, 0, 200, 200), plots[7].Mask.Rect)
+}
+
+func TestTransformInvert(t *testing.T) {
+	// --------------- Arrange ---------------
+	tr := mimage.Transform{ScaleX: 0.25, ScaleY: 2, OffsetX: -8, OffsetY: 3}
+
+	// --------------- Act ---------------
+	x, y := tr.Invert().Apply(tr.Apply(123, 45))
+
+	// --------------- Assert ---------------
+	assert.InDelta(t, 123, x, 1e-9)
+	assert.InDelta(t, 45, y, 1e-9)
+	assert.Equal(t, image.Pt(7, 9), mimage.IdentityTransform.Point(image.Pt(7, 9)))
+}
@@ -0,0 +1,114 @@
+package mimage
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"strings"
+)
+
+// error เมื่อใช้ WithStrict แล้วมีกรอบที่ไม่ถูกต้อง ดูรายละเอียดได้จาก *ValidationError
+var ErrInvalidPlot = errors.New("invalid plot data")
+
+// ข้อมูลของกรอบที่ไม่ผ่านการตรวจสอบ
+type InvalidPlot struct {
+	Index  int // ลำดับใน plotData ที่ส่งเข้ามา
+	Rect   image.Rectangle
+	Reason string
+}
+
+// error ที่บอกว่ากรอบไหนไม่ถูกต้อง สามารถเช็คด้วย errors.Is(err, ErrInvalidPlot)
+type ValidationError struct {
+	Invalid []InvalidPlot
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Invalid))
+	for i, p := range e.Invalid {
+		msgs[i] = fmt.Sprintf("#%d %v %s", p.Index, p.Rect, p.Reason)
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidPlot, strings.Join(msgs, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidPlot
+}
+
+// ตรวจว่าทุกกรอบเป็น rect ปกติ (Min <= Max), มีพื้นที่ และอยู่ในภาพทั้งหมด (รวม pixel ที่ Max)
+// Shape เช่นจุดหรือเส้นตรงไม่มีพื้นที่ได้ จึงตรวจเฉพาะว่าอยู่ในภาพ
+func validatePlots(bounds image.Rectangle, plots []PlotDataModel) error {
+	var invalid []InvalidPlot
+	for i, p := range plots {
+		var reason string
+		switch r := p.Rect; {
+		case p.Mask != nil && p.Shape == nil && p.Box == nil && r == (image.Rectangle{}):
+			// กรอบที่มีแต่ mask ไม่มีเส้นกรอบให้ตรวจ จึงตรวจเฉพาะ Mask.Rect ถ้ากำหนดไว้ (ไม่รวม pixel ที่ Max แบบ image.Rectangle)
+			if m := p.Mask.Rect; m.Empty() || m.In(bounds) {
+				continue
+			}
+			reason = fmt.Sprintf("mask %v is outside image bounds %v", p.Mask.Rect, bounds)
+		case p.Shape != nil:
+			// ขอบเขตที่กลับด้านหมายถึง Shape ไม่มีอะไรให้วาด (เช่น Keypoints ที่ถูกซ่อนทุกจุด) จึงไม่ถือว่าผิด
+			if r.Min.X > r.Max.X || r.Min.Y > r.Max.Y || inclusive(r).In(bounds) {
+				continue
+			}
+			reason = fmt.Sprintf("is outside image bounds %v", bounds)
+		case r.Min.X > r.Max.X || r.Min.Y > r.Max.Y:
+			reason = "is inverted (Min > Max)"
+		case r.Empty():
+			reason = "is empty"
+		case !inclusive(r).In(bounds):
+			reason = fmt.Sprintf("is outside image bounds %v", bounds)
+		default:
+			continue
+		}
+		invalid = append(invalid, InvalidPlot{Index: i, Rect: p.Rect, Reason: reason})
+	}
+
+	if len(invalid) > 0 {
+		return &ValidationError{Invalid: invalid}
+	}
+	return nil
+}
+
+// กลับด้าน rect ที่ Min > Max และตัดส่วนที่อยู่นอกภาพ คืนค่า false ถ้าไม่เหลือพื้นที่ให้วาด
+// เส้นกรอบวาดถึง pixel ที่ Max ด้วย จึงตัดให้ Max อยู่ใน pixel สุดท้ายของภาพ
+func clipRect(r, bounds image.Rectangle) (image.Rectangle, bool) {
+	clip := image.Rectangle{Min: bounds.Min, Max: bounds.Max.Sub(image.Pt(1, 1))}
+	r = r.Canon().Intersect(clip)
+	return r, !r.Empty()
+}
+
+// เหมือน clipRect แต่ใช้กับขอบเขตของ Shape ที่กว้างหรือสูงเป็น 0 ได้ (เช่นจุดหรือเส้นตรง)
+// ขอบเขตที่กลับด้านหมายถึง Shape ไม่มีอะไรให้วาด จึงไม่ถูกกลับให้เป็นปกติแบบ clipRect
+func clipShapeBounds(r, bounds image.Rectangle) (image.Rectangle, bool) {
+	r = inclusive(r).Intersect(bounds)
+	return image.Rectangle{Min: r.Min, Max: r.Max.Sub(image.Pt(1, 1))}, !r.Empty()
+}
+
+// พื้นที่บนภาพที่ p ครอบอยู่ (Max ไม่รวม pixel นั้น) สำหรับ Redact และ CropRegions คืนค่า false ถ้าไม่มีพื้นที่
+// กรอบที่มีแต่ mask จะใช้ขอบเขตของ pixel ใน mask ที่ไม่ใช่พื้นหลัง
+func plotArea(p PlotDataModel, bounds image.Rectangle) (image.Rectangle, bool) {
+	r := p.Rect
+	switch {
+	case p.Shape == nil && p.Box == nil && r == (image.Rectangle{}):
+		if p.Mask == nil || p.Mask.Data == nil {
+			return image.Rectangle{}, false
+		}
+		target := p.Mask.Rect
+		if target.Empty() {
+			target = bounds
+		}
+		area := p.Mask.extent(target)
+		return area, !area.Empty()
+	case p.Shape != nil && (r.Min.X > r.Max.X || r.Min.Y > r.Max.Y):
+		// Shape ที่ไม่มีอะไรให้วาด เช่น Keypoints ที่ถูกซ่อนทุกจุด
+		return image.Rectangle{}, false
+	}
+	return inclusive(r.Canon()), true
+}
+
+// แปลง rect ที่ Max เป็น pixel สุดท้ายที่วาด ให้เป็น image.Rectangle ปกติที่ไม่รวม Max
+func inclusive(r image.Rectangle) image.Rectangle {
+	return image.Rectangle{Min: r.Min, Max: r.Max.Add(image.Pt(1, 1))}
+}
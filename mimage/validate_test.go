@@ -0,0 +1,124 @@
+package mimage_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/inetmanageai/utils/mimage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlotImageEmptyPlotData(t *testing.T) {
+	for _, plotData := range [][]mimage.PlotDataModel{nil, {}} {
+		// --------------- Act ---------------
+		result, err := mimage.PlotImageFromBytes(createTestImage("png"), plotData)
+
+		// --------------- Assert ---------------
+		assert.NoError(t, err)
+		img, format, err := image.Decode(bytes.NewReader(result))
+		assert.NoError(t, err)
+		assert.Equal(t, "png", format)
+		assert.Equal(t, image.Rect(0, 0, 200, 200), img.Bounds())
+		assert.Equal(t, color.RGBA{255, 255, 255, 255}, color.RGBAModel.Convert(img.At(100, 100)))
+	}
+}
+
+func TestPlotImageOutOfBounds(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	white := color.RGBA{255, 255, 255, 255}
+	tests := []struct {
+		Name   string
+		Rect   image.Rectangle
+		Points map[image.Point]color.RGBA
+	}{
+		{
+			Name:   "Inverted rect is canonicalized",
+			Rect:   image.Rectangle{Min: image.Pt(50, 150), Max: image.Pt(10, 110)},
+			Points: map[image.Point]color.RGBA{{10, 130}: red, {50, 130}: red, {30, 130}: white},
+		},
+		{
+			Name:   "Partly outside rect is clipped to the image",
+			Rect:   image.Rect(150, 150, 300, 300),
+			Points: map[image.Point]color.RGBA{{199, 170}: red, {170, 199}: red, {170, 170}: white},
+		},
+		{
+			Name:   "Negative coordinates are clipped to the image",
+			Rect:   image.Rect(-50, 100, 50, 150),
+			Points: map[image.Point]color.RGBA{{0, 120}: red, {50, 120}: red, {25, 120}: white},
+		},
+		{
+			Name:   "Fully outside rect is skipped",
+			Rect:   image.Rect(300, 300, 400, 400),
+			Points: map[image.Point]color.RGBA{{199, 199}: white},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			result, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{{Rect: tt.Rect}})
+
+			// --------------- Assert ---------------
+			assert.NoError(t, err)
+			img, _, _ := image.Decode(bytes.NewReader(result))
+			for p, expected := range tt.Points {
+				assert.Equal(t, expected, color.RGBAModel.Convert(img.At(p.X, p.Y)), "pixel %v", p)
+			}
+		})
+	}
+}
+
+func TestPlotImageWithStrict(t *testing.T) {
+	// --------------- Arrange ---------------
+	plotData := []mimage.PlotDataModel{
+		{Rect: image.Rect(10, 10, 50, 50)},
+		{Rect: image.Rectangle{Min: image.Pt(50, 50), Max: image.Pt(10, 10)}},
+		{Rect: image.Rect(10, 10, 10, 50)},
+		{Rect: image.Rect(150, 150, 300, 300)},
+		{Rect: image.Rect(0, 0, 199, 199)},
+		{Rect: image.Rect(0, 0, 200, 200)},
+	}
+
+	// --------------- Act ---------------
+	result, err := mimage.PlotImageFromBytes(createTestImage("png"), plotData, mimage.WithStrict())
+
+	// --------------- Assert ---------------
+	assert.ErrorIs(t, err, mimage.ErrInvalidPlot)
+	assert.Zero(t, result)
+	var validationErr *mimage.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	indexes := make([]int, 0)
+	for _, p := range validationErr.Invalid {
+		indexes = append(indexes, p.Index)
+	}
+	assert.Equal(t, []int{1, 2, 3, 5}, indexes)
+}
+
+func TestPlotImageWithStrictMaskOnly(t *testing.T) {
+	data := image.NewGray(image.Rect(0, 0, 4, 4))
+	data.Pix[0] = 1
+	tests := []struct {
+		Name          string
+		Mask          mimage.Mask
+		ExpectedError bool
+	}{
+		{Name: "Whole image mask", Mask: mimage.Mask{Data: data}},
+		{Name: "Mask rect inside image", Mask: mimage.Mask{Data: data, Rect: image.Rect(0, 0, 200, 200)}},
+		{Name: "Mask rect outside image", Mask: mimage.Mask{Data: data, Rect: image.Rect(150, 150, 250, 250)}, ExpectedError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// --------------- Act ---------------
+			_, err := mimage.PlotImageFromBytes(createTestImage("png"), []mimage.PlotDataModel{{Mask: &tt.Mask}}, mimage.WithStrict())
+
+			// --------------- Assert ---------------
+			if tt.ExpectedError {
+				assert.ErrorIs(t, err, mimage.ErrInvalidPlot)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}